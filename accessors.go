@@ -0,0 +1,215 @@
+package dataflash
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrFieldNotFound is wrapped by the errors of the typed accessors when
+	// the message has no such field.
+	ErrFieldNotFound = errors.New("field not found")
+	// ErrFieldType is wrapped by the errors of the typed accessors when the
+	// field value cannot be converted to the requested type.
+	ErrFieldType = errors.New("incompatible field type")
+)
+
+// FieldError reports a field that a typed accessor such as Message.Int64
+// cannot return.
+type FieldError struct {
+	Message string // Message name
+	Field   string // Field name
+	Type    string // Requested type, such as "int64"
+	Value   any    // Field value, nil if the field is missing
+	Err     error  // ErrFieldNotFound or ErrFieldType
+}
+
+func (e *FieldError) Error() string {
+	if e.Err == ErrFieldNotFound {
+		return fmt.Sprintf("%s.%s: %v", e.Message, e.Field, e.Err)
+	}
+	return fmt.Sprintf("%s.%s: %v: cannot convert %T(%v) to %s", e.Message, e.Field, e.Err, e.Value, e.Value, e.Type)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// field returns the value of the named field, or a *FieldError wrapping
+// ErrFieldNotFound.
+func (m *Message) field(name, typ string) (any, error) {
+	value, ok := m.Fields[name]
+	if !ok {
+		return nil, &FieldError{Message: m.Name, Field: name, Type: typ, Err: ErrFieldNotFound}
+	}
+	return value, nil
+}
+
+// typeError returns a *FieldError wrapping ErrFieldType.
+func (m *Message) typeError(name, typ string, value any) error {
+	return &FieldError{Message: m.Name, Field: name, Type: typ, Value: value, Err: ErrFieldType}
+}
+
+// Int64 returns an integer field as an int64. It fails for uint64 values
+// above math.MaxInt64 and for float, string and array fields.
+func (m *Message) Int64(field string) (int64, error) {
+	value, err := m.field(field, "int64")
+	if err != nil {
+		return 0, err
+	}
+	switch v := value.(type) {
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		if v <= math.MaxInt64 {
+			return int64(v), nil
+		}
+	}
+	return 0, m.typeError(field, "int64", value)
+}
+
+// Uint64 returns an integer field as a uint64. It fails for negative values
+// and for float, string and array fields.
+func (m *Message) Uint64(field string) (uint64, error) {
+	value, err := m.field(field, "uint64")
+	if err != nil {
+		return 0, err
+	}
+	switch v := value.(type) {
+	case uint8:
+		return uint64(v), nil
+	case uint16:
+		return uint64(v), nil
+	case uint32:
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	}
+	if v, err := m.Int64(field); err == nil && v >= 0 {
+		return uint64(v), nil
+	}
+	return 0, m.typeError(field, "uint64", value)
+}
+
+// Float64 returns a numeric field as a float64, with the fixed scaling of
+// the c, C, e, E and L formats that DecodeMessageBody applies. Integers
+// beyond 2^53 are rounded. It fails for string and array fields.
+func (m *Message) Float64(field string) (float64, error) {
+	value, err := m.field(field, "float64")
+	if err != nil {
+		return 0, err
+	}
+	switch v := value.(type) {
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case uint64:
+		return float64(v), nil
+	}
+	if v, err := m.Int64(field); err == nil {
+		return float64(v), nil
+	}
+	return 0, m.typeError(field, "float64", value)
+}
+
+// MustFloat64 is like Float64 but panics if the field is missing or not
+// numeric. It suits fields known to exist, such as those of a filtered
+// message type.
+func (m *Message) MustFloat64(field string) float64 {
+	v, err := m.Float64(field)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ScaledFloat64 returns a numeric field in SI units: the FMTU multiplier is
+// applied as by GetScaled, so TimeUS is returned in seconds. It fails like
+// Float64, and for messages without a schema.
+func (m *Message) ScaledFloat64(field string) (float64, error) {
+	v, err := m.Float64(field)
+	if err != nil {
+		return 0, err
+	}
+	if m.schema == nil {
+		return 0, fmt.Errorf("no schema available for message")
+	}
+
+	i := m.schema.ColumnIndex(field)
+	if i < 0 {
+		return v, nil
+	}
+	def := m.schema.Fields()[i]
+	if !formatHasScaling(def.Format) && def.Mult != '-' && def.Mult != '?' && def.Mult != '0' {
+		v *= getMultiplier(def.Mult)
+	}
+	return v, nil
+}
+
+// String returns a string field (n, N or Z). It fails for other fields.
+func (m *Message) String(field string) (string, error) {
+	value, err := m.field(field, "string")
+	if err != nil {
+		return "", err
+	}
+	if v, ok := value.(string); ok {
+		return v, nil
+	}
+	return "", m.typeError(field, "string", value)
+}
+
+// Bytes returns a string field (n, N or Z) as a new byte slice, for fields
+// that hold binary data. It fails for other fields.
+func (m *Message) Bytes(field string) ([]byte, error) {
+	value, err := m.field(field, "[]byte")
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := value.(string); ok {
+		return []byte(v), nil
+	}
+	return nil, m.typeError(field, "[]byte", value)
+}
+
+// Bool returns whether an integer field, such as a flag stored as B, is
+// non-zero. It fails for float, string and array fields.
+func (m *Message) Bool(field string) (bool, error) {
+	value, err := m.field(field, "bool")
+	if err != nil {
+		return false, err
+	}
+	if v, err := m.Uint64(field); err == nil {
+		return v != 0, nil
+	}
+	if v, err := m.Int64(field); err == nil {
+		return v != 0, nil
+	}
+	return false, m.typeError(field, "bool", value)
+}
+
+// Int16Array returns an array field (format a). The slice is shared with
+// Fields. It fails for other fields.
+func (m *Message) Int16Array(field string) ([]int16, error) {
+	value, err := m.field(field, "[]int16")
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := value.([]int16); ok {
+		return v, nil
+	}
+	return nil, m.typeError(field, "[]int16", value)
+}
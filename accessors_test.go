@@ -0,0 +1,123 @@
+package dataflash
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAccessors(t *testing.T) {
+	msg := &Message{Name: "TEST", Fields: map[string]any{
+		"u8":    uint8(200),
+		"u64":   uint64(math.MaxUint64),
+		"i8":    int8(-5),
+		"i64":   int64(1 << 40),
+		"f32":   float32(1.5),
+		"f64":   float64(-2.25),
+		"str":   "hello",
+		"zero":  uint16(0),
+		"array": []int16{1, -2},
+	}}
+
+	ints := map[string]int64{"u8": 200, "i8": -5, "i64": 1 << 40, "zero": 0}
+	for field, want := range ints {
+		if got, err := msg.Int64(field); err != nil || got != want {
+			t.Errorf("Int64(%s): expected %d, got %d, %v", field, want, got, err)
+		}
+		if got, err := msg.Float64(field); err != nil || got != float64(want) {
+			t.Errorf("Float64(%s): expected %d, got %v, %v", field, want, got, err)
+		}
+		if got, err := msg.Bool(field); err != nil || got != (want != 0) {
+			t.Errorf("Bool(%s): expected %v, got %v, %v", field, want != 0, got, err)
+		}
+	}
+
+	if got, err := msg.Uint64("u64"); err != nil || got != math.MaxUint64 {
+		t.Errorf("Uint64(u64): got %d, %v", got, err)
+	}
+	if got, err := msg.Uint64("i64"); err != nil || got != 1<<40 {
+		t.Errorf("Uint64(i64): got %d, %v", got, err)
+	}
+	if got, err := msg.Float64("f32"); err != nil || got != 1.5 {
+		t.Errorf("Float64(f32): got %v, %v", got, err)
+	}
+	if got := msg.MustFloat64("f64"); got != -2.25 {
+		t.Errorf("MustFloat64(f64): got %v", got)
+	}
+	if got, err := msg.String("str"); err != nil || got != "hello" {
+		t.Errorf("String(str): got %q, %v", got, err)
+	}
+	if got, err := msg.Bytes("str"); err != nil || string(got) != "hello" {
+		t.Errorf("Bytes(str): got %q, %v", got, err)
+	}
+	if got, err := msg.Int16Array("array"); err != nil || len(got) != 2 || got[1] != -2 {
+		t.Errorf("Int16Array(array): got %v, %v", got, err)
+	}
+
+	incompatible := []struct {
+		name string
+		get  func() error
+	}{
+		{"Int64(u64)", func() error { _, err := msg.Int64("u64"); return err }},
+		{"Int64(f64)", func() error { _, err := msg.Int64("f64"); return err }},
+		{"Uint64(i8)", func() error { _, err := msg.Uint64("i8"); return err }},
+		{"Float64(str)", func() error { _, err := msg.Float64("str"); return err }},
+		{"Float64(array)", func() error { _, err := msg.Float64("array"); return err }},
+		{"String(u8)", func() error { _, err := msg.String("u8"); return err }},
+		{"Bytes(array)", func() error { _, err := msg.Bytes("array"); return err }},
+		{"Bool(f32)", func() error { _, err := msg.Bool("f32"); return err }},
+		{"Int16Array(str)", func() error { _, err := msg.Int16Array("str"); return err }},
+	}
+	for _, tt := range incompatible {
+		err := tt.get()
+		var fieldErr *FieldError
+		if !errors.Is(err, ErrFieldType) || !errors.As(err, &fieldErr) || fieldErr.Value == nil {
+			t.Errorf("%s: expected a *FieldError wrapping ErrFieldType, got %v", tt.name, err)
+		}
+	}
+
+	_, err := msg.Float64("missing")
+	if !errors.Is(err, ErrFieldNotFound) || errors.Is(err, ErrFieldType) {
+		t.Errorf("expected ErrFieldNotFound, got %v", err)
+	}
+	if err.Error() != "TEST.missing: field not found" {
+		t.Errorf("unexpected message: %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustFloat64 to panic for a missing field")
+		}
+	}()
+	msg.MustFloat64("missing")
+}
+
+func TestScaledFloat64(t *testing.T) {
+	parser, err := NewParserFromBytes(sampleLog(10))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if err := parser.SetFilter("GPS"); err != nil {
+		t.Fatalf("failed to set filter: %v", err)
+	}
+	msg, err := parser.ReadMessage()
+	if err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+
+	// TimeUS has multiplier F (1e-6)
+	if got, err := msg.ScaledFloat64("TimeUS"); err != nil || math.Abs(got-0.01) > 1e-12 {
+		t.Errorf("expected 0.01 s, got %v, %v", got, err)
+	}
+	// Lat is scaled by its format, so its multiplier is not applied again
+	if got, err := msg.ScaledFloat64("Lat"); err != nil || math.Abs(got-50.45) > 1e-6 {
+		t.Errorf("expected 50.45 deg, got %v, %v", got, err)
+	}
+	// Status has no multiplier
+	if got, err := msg.ScaledFloat64("Status"); err != nil || got != 3 {
+		t.Errorf("expected 3, got %v, %v", got, err)
+	}
+	if _, err := msg.ScaledFloat64("Missing"); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("expected ErrFieldNotFound, got %v", err)
+	}
+}
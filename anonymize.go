@@ -0,0 +1,554 @@
+package dataflash
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	metresPerDegree = 6378137 * math.Pi / 180 // Along a meridian, on the WGS84 equatorial radius
+	gpsWeekMS       = 7 * 24 * 60 * 60 * 1000 // Length of a GPS week in milliseconds
+	maxOriginLat    = 85                      // Fake origins closer to the poles distort tracks
+)
+
+// ChangeKind describes how Anonymize changed a field.
+type ChangeKind string
+
+const (
+	ChangeLocation ChangeKind = "location" // Latitude or longitude moved
+	ChangeAltitude ChangeKind = "altitude" // Altitude shifted
+	ChangeGPSTime  ChangeKind = "gps time" // GPS week and time of week shifted
+	ChangeRedacted ChangeKind = "redacted" // Parts of a string masked
+)
+
+// AnonymizeReport lists what Anonymize changed. It names fields but never
+// the original values or the offsets applied, so it can be published with
+// the log.
+type AnonymizeReport struct {
+	Messages   int64             // Messages written
+	Changed    int64             // Messages with at least one field changed
+	Fields     []AnonymizedField // Changed columns, in the order they were first changed
+	Redactions []Redaction       // Masked strings, in log order
+}
+
+// AnonymizedField counts the changes to one column of a message type.
+type AnonymizedField struct {
+	Message string     // Message name
+	Column  string     // Column name
+	Kind    ChangeKind // How the column was changed
+	Count   int64      // Messages in which the column changed
+}
+
+// Redaction is a string field in which Anonymize masked identifiers.
+type Redaction struct {
+	LineNo  int64  // LineNo of the message
+	Message string // Message name
+	Column  string // Column name
+	Text    string // The string as written, with masked characters replaced by '*'
+}
+
+// AnonymizeOption configures Anonymize.
+type AnonymizeOption func(*anonymizer)
+
+// WithLocationOffset moves every position by the given number of degrees of
+// latitude and longitude instead of rotating the track around a fake
+// origin. Shapes and distances are preserved exactly but the offset must be
+// kept secret, as one known point reveals it.
+func WithLocationOffset(lat, lng float64) AnonymizeOption {
+	return func(a *anonymizer) {
+		a.shift = true
+		a.latOffset, a.lngOffset = lat, lng
+	}
+}
+
+// WithFakeOrigin moves the first position of the log to lat, lng and
+// rotates the track around it by rotation degrees clockwise. Distances are
+// preserved within the accuracy of a flat-earth approximation, which is
+// well below GPS noise for flights of a few tens of kilometres. By default
+// the origin and rotation are random.
+func WithFakeOrigin(lat, lng, rotation float64) AnonymizeOption {
+	return func(a *anonymizer) {
+		a.shift = false
+		a.originLat, a.originLng = lat, lng
+		a.setRotation(rotation)
+	}
+}
+
+// WithAltitudeOffset adds metres to the altitudes of messages that also
+// carry a position. By default a random offset of 100 to 1000 m up or down
+// is added, so that altitudes cannot be matched against terrain; 0 keeps
+// them unchanged.
+func WithAltitudeOffset(metres float64) AnonymizeOption {
+	return func(a *anonymizer) {
+		a.altOffset = metres
+	}
+}
+
+// WithGPSTimeShift adds d, rounded to milliseconds, to the GPS week and
+// time of week. By default GPS time is moved back by a random whole number
+// of weeks between one and ten years, which keeps the weekday and time of
+// day.
+func WithGPSTimeShift(d time.Duration) AnonymizeOption {
+	return func(a *anonymizer) {
+		a.timeShiftMS = d.Milliseconds()
+	}
+}
+
+// WithRedaction adds patterns to mask in the strings of MSG and VER
+// messages. If a pattern has a parenthesized subexpression, only the text
+// it matches is masked, so a label such as "serial:" can stay readable.
+func WithRedaction(patterns ...*regexp.Regexp) AnonymizeOption {
+	return func(a *anonymizer) {
+		a.redactions = append(a.redactions, patterns...)
+	}
+}
+
+// defaultRedactions mask the identifiers ArduPilot prints in MSG messages.
+var defaultRedactions = []*regexp.Regexp{
+	// Board unique ID printed at boot, e.g. "CubeOrange 003C002E 31395106 37383431"
+	regexp.MustCompile(`\b[0-9A-Fa-f]{8}(?: [0-9A-Fa-f]{8}){2,}\b`),
+	// Labelled serial numbers and IDs, e.g. "Serial: 12345678"
+	regexp.MustCompile(`(?i)\b(?:serial(?: ?(?:no|number))?|s/n|uid)\s*[:=#]?\s*([0-9a-z][0-9a-z-]{3,})`),
+}
+
+// Anonymize writes src to dst with the places and times it was recorded
+// hidden, so that it can be shared publicly:
+//
+//   - latitudes and longitudes are moved, see WithFakeOrigin and
+//     WithLocationOffset: every L field, and other numeric columns named
+//     Lat, Lng or Lon. L fields are paired by the column name ending in Lat,
+//     Lng or Lon, and otherwise in column order, the first of each pair as
+//     the latitude. 0, 0 is left alone as it means no position
+//   - Alt and TerrH columns of messages with a position are shifted by a
+//     secret offset, see WithAltitudeOffset
+//   - GWk and GMS columns are shifted, see WithGPSTimeShift
+//   - board IDs and serial numbers in the strings of MSG and VER messages
+//     are masked with '*', see WithRedaction
+//
+// All other bytes are copied unchanged, including TimeUS, which counts from
+// boot. Parameters such as home or simulator locations are not changed.
+// src is read from the start; pass a parser of the whole log rather than a
+// segment view. The parser is rewound when done.
+// Returns ErrNotSeekable for stream parsers.
+func Anonymize(src *Parser, dst io.Writer, opts ...AnonymizeOption) (*AnonymizeReport, error) {
+	return AnonymizeContext(context.Background(), src, dst, opts...)
+}
+
+// AnonymizeContext is like Anonymize but stops with ctx.Err() once ctx is
+// done.
+func AnonymizeContext(ctx context.Context, src *Parser, dst io.Writer, opts ...AnonymizeOption) (*AnonymizeReport, error) {
+	a := newAnonymizer(opts)
+	if !a.shift && math.Abs(a.originLat) > maxOriginLat {
+		return nil, fmt.Errorf("fake origin latitude %v is out of range", a.originLat)
+	}
+	if err := src.Rewind(); err != nil {
+		return nil, err
+	}
+	if !a.shift {
+		if err := a.findReference(ctx, src); err != nil {
+			return nil, err
+		}
+	}
+
+	w := bufio.NewWriter(dst)
+	for {
+		if err := checkContext(ctx, src.lineNo); err != nil {
+			return nil, err
+		}
+
+		schema, body, err := src.skipMessage()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		src.reportProgress(StageAnonymize, src.lineNo, false)
+
+		a.report.Messages++
+		if plan := a.plan(schema); plan != nil {
+			a.buf = append(a.buf[:0], body...)
+			changed, err := a.apply(plan, schema, a.buf, src.lineNo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to anonymize message %d: %w", src.lineNo, err)
+			}
+			if changed {
+				a.report.Changed++
+			}
+			body = a.buf
+		}
+		w.Write([]byte{HEAD1, HEAD2, schema.Type})
+		w.Write(body)
+	}
+	src.reportProgress(StageAnonymize, src.lineNo, true)
+
+	if err := w.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to write anonymized log: %w", err)
+	}
+	return a.report, src.Rewind()
+}
+
+// anonymizer holds the settings and state of one Anonymize call.
+type anonymizer struct {
+	shift                bool    // Shift by latOffset and lngOffset instead of rotating
+	latOffset, lngOffset float64 // Degrees added when shifting
+	originLat, originLng float64 // Where the reference is moved when rotating
+	sin, cos             float64 // Of the clockwise rotation
+	refLat, refLng       float64 // First latitude and longitude of the log
+	altOffset            float64
+	timeShiftMS          int64
+	redactions           []*regexp.Regexp
+
+	plans  map[*Schema]*anonPlan
+	fields map[[2]string]int // Index in report.Fields by message and column
+	report *AnonymizeReport
+	buf    []byte // Message being rewritten
+}
+
+// newAnonymizer returns an anonymizer with random defaults and opts applied.
+func newAnonymizer(opts []AnonymizeOption) *anonymizer {
+	a := &anonymizer{
+		originLat:   rand.Float64()*120 - 60,
+		originLng:   rand.Float64()*360 - 180,
+		altOffset:   (100 + rand.Float64()*900) * float64(1-2*rand.IntN(2)),
+		timeShiftMS: -int64(52+rand.IntN(469)) * gpsWeekMS,
+		redactions:  slices.Clone(defaultRedactions),
+		plans:       make(map[*Schema]*anonPlan),
+		fields:      make(map[[2]string]int),
+		report:      &AnonymizeReport{},
+	}
+	a.setRotation(rand.Float64() * 360)
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// setRotation sets the rotation in degrees clockwise.
+func (a *anonymizer) setRotation(degrees float64) {
+	a.sin, a.cos = math.Sincos(degrees * math.Pi / 180)
+}
+
+// anonField is a field holding a number in degrees or metres once
+// multiplied by scale.
+type anonField struct {
+	index int // Index in Schema.Fields, -1 if none
+	scale float64
+}
+
+// coordPair is a latitude and longitude sharing a column prefix. Either may
+// be missing.
+type coordPair struct {
+	lat, lng anonField
+}
+
+// anonPlan lists the fields of a schema that Anonymize changes.
+type anonPlan struct {
+	coords   []coordPair
+	alts     []anonField
+	week, ms int   // GPS week and time of week, -1 if none
+	strs     []int // String fields to redact
+}
+
+// plan returns the fields of schema to change, or nil if there are none.
+func (a *anonymizer) plan(schema *Schema) *anonPlan {
+	if plan, ok := a.plans[schema]; ok {
+		return plan
+	}
+	plan := &anonPlan{week: schema.ColumnIndex("GWk"), ms: schema.ColumnIndex("GMS")}
+	if plan.week < 0 || plan.ms < 0 {
+		plan.week, plan.ms = -1, -1
+	}
+
+	pairs := make(map[string]int) // Index in plan.coords by column prefix
+	var unnamed []int             // L fields without a coordinate name
+	for i, field := range schema.Fields() {
+		name := strings.ToLower(field.Name)
+		if field.Format == 'n' || field.Format == 'N' || field.Format == 'Z' {
+			if schema.Name == "MSG" || schema.Name == "VER" {
+				plan.strs = append(plan.strs, i)
+			}
+			continue
+		}
+
+		// Other formats only count under the plain names, as columns such
+		// as AccLat are not positions
+		isLat := strings.HasSuffix(name, "lat")
+		if !isLat && !strings.HasSuffix(name, "lng") && !strings.HasSuffix(name, "lon") {
+			if field.Format == 'L' {
+				unnamed = append(unnamed, i)
+			}
+			continue
+		}
+		prefix := name[:len(name)-3]
+		scale, ok := coordScale(field.Format)
+		if !ok || field.Format != 'L' && prefix != "" {
+			continue
+		}
+		j, ok := pairs[prefix]
+		if !ok {
+			j = len(plan.coords)
+			pairs[prefix] = j
+			plan.coords = append(plan.coords, coordPair{anonField{-1, 0}, anonField{-1, 0}})
+		}
+		if isLat {
+			plan.coords[j].lat = anonField{i, scale}
+		} else {
+			plan.coords[j].lng = anonField{i, scale}
+		}
+	}
+
+	// L always holds a coordinate, so fail closed on columns with other
+	// names; a last unpaired one is moved as a latitude
+	for len(unnamed) > 0 {
+		c := coordPair{anonField{unnamed[0], 1}, anonField{-1, 0}}
+		if len(unnamed) > 1 {
+			c.lng = anonField{unnamed[1], 1}
+			unnamed = unnamed[1:]
+		}
+		plan.coords = append(plan.coords, c)
+		unnamed = unnamed[1:]
+	}
+
+	if len(plan.coords) > 0 {
+		for _, column := range []string{"Alt", "TerrH"} {
+			i := schema.ColumnIndex(column)
+			if i < 0 {
+				continue
+			}
+			if scale, ok := altScale(schema.Fields()[i].Format); ok {
+				plan.alts = append(plan.alts, anonField{i, scale})
+			}
+		}
+	}
+
+	if len(plan.coords) == 0 && len(plan.alts) == 0 && plan.week < 0 && len(plan.strs) == 0 {
+		plan = nil
+	}
+	a.plans[schema] = plan
+	return plan
+}
+
+// coordScale returns the factor converting a latitude or longitude field of
+// the given format to degrees.
+func coordScale(format rune) (float64, bool) {
+	switch format {
+	case 'L', 'f', 'd':
+		return 1, true
+	case 'i': // Degrees * 1e7, as in L
+		return 1e-7, true
+	}
+	return 0, false
+}
+
+// altScale returns the factor converting an altitude field of the given
+// format to metres.
+func altScale(format rune) (float64, bool) {
+	switch format {
+	case 'e', 'f', 'd':
+		return 1, true
+	case 'i': // Centimetres
+		return 0.01, true
+	}
+	return 0, false
+}
+
+// get returns the value of f in degrees or metres.
+func (f anonField) get(r *FieldReader) float64 {
+	return r.Float64(f.index) * f.scale
+}
+
+// set stores v, in degrees or metres, in f and reports whether the stored
+// bytes changed.
+func (f anonField) set(r *FieldReader, v float64) (bool, error) {
+	field := r.schema.fields[f.index]
+	b := r.body[field.Offset : field.Offset+field.Size]
+	old := string(b)
+	var value any = v
+	if f.scale != 1 {
+		value = math.Round(v / f.scale)
+	}
+	if err := encodeField(b, field.Format, value); err != nil {
+		return false, fmt.Errorf("%s.%s: %w", r.schema.Name, field.Name, err)
+	}
+	return string(b) != old, nil
+}
+
+// findReference reads src up to its first non-zero latitude and longitude,
+// which are moved to the fake origin, and rewinds it.
+func (a *anonymizer) findReference(ctx context.Context, p *Parser) error {
+	haveLat, haveLng := false, false
+	for !haveLat || !haveLng {
+		if err := checkContext(ctx, p.lineNo); err != nil {
+			return err
+		}
+
+		schema, body, err := p.skipMessage()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		p.reportProgress(StageAnonymize, p.lineNo, false)
+
+		plan := a.plan(schema)
+		if plan == nil {
+			continue
+		}
+		r := FieldReader{schema: schema, body: body}
+		for _, c := range plan.coords {
+			if c.lat.index >= 0 && !haveLat {
+				a.refLat = c.lat.get(&r)
+				haveLat = a.refLat != 0
+			}
+			if c.lng.index >= 0 && !haveLng {
+				a.refLng = c.lng.get(&r)
+				haveLng = a.refLng != 0
+			}
+		}
+	}
+	return p.Rewind()
+}
+
+// move returns the anonymized position of lat, lng.
+func (a *anonymizer) move(lat, lng float64) (float64, float64) {
+	if a.shift {
+		lat += a.latOffset
+		lng += a.lngOffset
+	} else {
+		north := (lat - a.refLat) * metresPerDegree
+		east := math.Remainder(lng-a.refLng, 360) * metresPerDegree * math.Cos(a.refLat*math.Pi/180)
+		north, east = north*a.cos-east*a.sin, north*a.sin+east*a.cos
+		lat = a.originLat + north/metresPerDegree
+		lng = a.originLng + east/(metresPerDegree*math.Cos(a.originLat*math.Pi/180))
+	}
+	return max(-90, min(90, lat)), math.Remainder(lng, 360)
+}
+
+// apply rewrites the fields in plan of the message body and reports whether
+// any changed.
+func (a *anonymizer) apply(plan *anonPlan, schema *Schema, body []byte, lineNo int64) (bool, error) {
+	r := FieldReader{schema: schema, body: body}
+	changed := false
+	update := func(f anonField, v float64, kind ChangeKind) error {
+		ok, err := f.set(&r, v)
+		if ok {
+			a.record(schema, f.index, kind)
+			changed = true
+		}
+		return err
+	}
+
+	moved := false
+	for _, c := range plan.coords {
+		lat, lng := a.refLat, a.refLng
+		if c.lat.index >= 0 {
+			lat = c.lat.get(&r)
+		}
+		if c.lng.index >= 0 {
+			lng = c.lng.get(&r)
+		}
+		if (c.lat.index < 0 || lat == 0) && (c.lng.index < 0 || lng == 0) {
+			continue // No position
+		}
+		moved = true
+		newLat, newLng := a.move(lat, lng)
+		if c.lat.index >= 0 {
+			if err := update(c.lat, newLat, ChangeLocation); err != nil {
+				return false, err
+			}
+		}
+		if c.lng.index >= 0 {
+			if err := update(c.lng, newLng, ChangeLocation); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if moved && a.altOffset != 0 {
+		for _, f := range plan.alts {
+			if err := update(f, f.get(&r)+a.altOffset, ChangeAltitude); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if plan.week >= 0 && a.timeShiftMS != 0 {
+		if week := r.Uint64(plan.week); week != 0 {
+			ms := int64(week)*gpsWeekMS + int64(r.Uint64(plan.ms)) + a.timeShiftMS
+			if ms < 0 {
+				return false, fmt.Errorf("GPS time shifted before the GPS epoch")
+			}
+			weekField := anonField{plan.week, 1}
+			msField := anonField{plan.ms, 1}
+			if err := update(weekField, float64(ms/gpsWeekMS), ChangeGPSTime); err != nil {
+				return false, err
+			}
+			if err := update(msField, float64(ms%gpsWeekMS), ChangeGPSTime); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	for _, i := range plan.strs {
+		field := schema.fields[i]
+		b := body[field.Offset : field.Offset+field.Size]
+		if !a.redact(b) {
+			continue
+		}
+		a.record(schema, i, ChangeRedacted)
+		a.report.Redactions = append(a.report.Redactions, Redaction{
+			LineNo:  lineNo,
+			Message: schema.Name,
+			Column:  field.Name,
+			Text:    string(bytes.TrimRight(b, "\x00")),
+		})
+		changed = true
+	}
+	return changed, nil
+}
+
+// redact masks the matches of the redaction patterns in b with '*', except
+// for spaces, and reports whether any were found.
+func (a *anonymizer) redact(b []byte) bool {
+	text := bytes.TrimRight(b, "\x00")
+	masked := false
+	for _, re := range a.redactions {
+		for _, m := range re.FindAllSubmatchIndex(text, -1) {
+			start, end := m[0], m[1]
+			if len(m) >= 4 && m[2] >= 0 {
+				start, end = m[2], m[3]
+			}
+			for i := start; i < end; i++ {
+				if text[i] != ' ' {
+					text[i] = '*'
+				}
+			}
+			masked = masked || end > start
+		}
+	}
+	return masked
+}
+
+// record counts a change to field i of schema in the report.
+func (a *anonymizer) record(schema *Schema, i int, kind ChangeKind) {
+	column := schema.fields[i].Name
+	key := [2]string{schema.Name, column}
+	j, ok := a.fields[key]
+	if !ok {
+		j = len(a.report.Fields)
+		a.fields[key] = j
+		a.report.Fields = append(a.report.Fields, AnonymizedField{Message: schema.Name, Column: column, Kind: kind})
+	}
+	a.report.Fields[j].Count++
+}
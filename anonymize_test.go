@@ -0,0 +1,215 @@
+package dataflash
+
+import (
+	"bytes"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	testGPSTimeSchema = &Schema{Type: 154, Length: 25, Name: "GPT", Format: "QHILL", Columns: "TimeUS,GWk,GMS,Lat,Lng"}
+	testPOSSchema     = &Schema{Type: 155, Length: 23, Name: "POS", Format: "QLLf", Columns: "TimeUS,Lat,Lng,Alt"}
+	testVERSchema     = &Schema{Type: 156, Length: 75, Name: "VER", Format: "QZ", Columns: "TimeUS,FWS"}
+)
+
+// privateLog returns a log with a GPS message without a fix, then 10 GPS,
+// GPT and POS messages heading north from 50.45, 30.52, and MSG and VER
+// messages with board identifiers.
+func privateLog() []byte {
+	var l testLog
+	l.writePreamble()
+	for _, s := range []*Schema{testMsgSchema, testGPSTimeSchema, testPOSSchema, testVERSchema} {
+		l.writeFMT(s)
+	}
+	l.writeMessage(testVERSchema.Type, uint64(0))
+	l.writeString("ArduCopter V4.5.1 (b4f0e3c5)", 64)
+	l.writeMsg(0, "CubeOrange 003C002E 31395106 37383431")
+	l.writeMsg(0, "GPS 1: u-blox serial: AB12345")
+	l.writeGPS(500, 0, 0)
+	for i := range 10 {
+		timeUS := uint64(1000 * (i + 1))
+		lat := 50.45 + 0.001*float64(i)
+		l.writeIMU(timeUS)
+		l.writeGPS(timeUS, lat, 30.52)
+		l.writeMessage(testGPSTimeSchema.Type, timeUS, uint16(2300), uint32(1000*i), int32(lat*1e7), int32(30.52*1e7))
+		l.writeMessage(testPOSSchema.Type, timeUS, int32(lat*1e7), int32(30.52*1e7), float32(180.5))
+	}
+	return l.Bytes()
+}
+
+// anonymize runs Anonymize on data and returns the messages written.
+func anonymize(t *testing.T, data []byte, opts ...AnonymizeOption) ([]*Message, *AnonymizeReport) {
+	t.Helper()
+	parser, err := NewParserFromBytes(data)
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	var out bytes.Buffer
+	report, err := Anonymize(parser, &out, opts...)
+	if err != nil {
+		t.Fatalf("failed to anonymize: %v", err)
+	}
+	if out.Len() != len(data) {
+		t.Errorf("expected %d bytes, got %d", len(data), out.Len())
+	}
+	return readTrimmed(t, out.Bytes()), report
+}
+
+func TestAnonymizeOffset(t *testing.T) {
+	data := privateLog()
+	messages, report := anonymize(t, data,
+		WithLocationOffset(1, -2),
+		WithAltitudeOffset(100),
+		WithGPSTimeShift(-14*24*time.Hour+time.Second))
+
+	var gps, gpt, pos []*Message
+	var texts []string
+	for _, msg := range messages {
+		switch msg.Name {
+		case "GPS":
+			gps = append(gps, msg)
+		case "GPT":
+			gpt = append(gpt, msg)
+		case "POS":
+			pos = append(pos, msg)
+		case "MSG":
+			texts = append(texts, msg.Fields["Message"].(string))
+		case "VER":
+			texts = append(texts, msg.Fields["FWS"].(string))
+		case "IMU":
+			if msg.Fields["GyrX"] != float32(0.1) {
+				t.Errorf("expected IMU messages to be unchanged, got %v", msg.Fields)
+			}
+		}
+	}
+
+	if gps[0].Fields["Lat"] != 0.0 || gps[0].Fields["Alt"] != float64(27530)*0.01 {
+		t.Errorf("expected a message without a fix to be unchanged, got %v", gps[0].Fields)
+	}
+	for i := range 10 {
+		lat := 51.45 + 0.001*float64(i)
+		if math.Abs(gps[i+1].MustFloat64("Lat")-lat) > 2e-7 || math.Abs(pos[i].MustFloat64("Lng")-28.52) > 2e-7 {
+			t.Errorf("message %d: unexpected position %v, %v", i, gps[i+1].Fields, pos[i].Fields)
+		}
+		if gps[i+1].Fields["Alt"] != float64(37530)*0.01 || pos[i].Fields["Alt"] != float32(280.5) {
+			t.Errorf("message %d: unexpected altitude %v, %v", i, gps[i+1].Fields["Alt"], pos[i].Fields["Alt"])
+		}
+		if gpt[i].Fields["GWk"] != uint16(2298) || gpt[i].Fields["GMS"] != uint32(1000+1000*i) {
+			t.Errorf("message %d: unexpected GPS time %v", i, gpt[i].Fields)
+		}
+	}
+
+	expectedTexts := []string{
+		"ArduCopter V4.5.1 (b4f0e3c5)",
+		"CubeOrange ******** ******** ********",
+		"GPS 1: u-blox serial: *******",
+	}
+	if strings.Join(texts, "|") != strings.Join(expectedTexts, "|") {
+		t.Errorf("expected %q, got %q", expectedTexts, texts)
+	}
+
+	expected := []AnonymizedField{
+		{"MSG", "Message", ChangeRedacted, 2},
+		{"GPS", "Lat", ChangeLocation, 10},
+		{"GPS", "Lng", ChangeLocation, 10},
+		{"GPS", "Alt", ChangeAltitude, 10},
+		{"GPT", "Lat", ChangeLocation, 10},
+		{"GPT", "Lng", ChangeLocation, 10},
+		{"GPT", "GWk", ChangeGPSTime, 10},
+		{"GPT", "GMS", ChangeGPSTime, 10},
+	}
+	for i, want := range expected {
+		if i >= len(report.Fields) || report.Fields[i] != want {
+			t.Errorf("expected field %d to be %+v, got %+v", i, want, report.Fields)
+			break
+		}
+	}
+	if len(report.Redactions) != 2 || report.Redactions[1].LineNo != 13 || report.Redactions[1].Text != expectedTexts[2] {
+		t.Errorf("unexpected redactions %+v", report.Redactions)
+	}
+	if report.Messages != int64(len(messages)) || report.Changed != 32 {
+		t.Errorf("expected %d messages with 32 changed, got %d and %d", len(messages), report.Messages, report.Changed)
+	}
+}
+
+func TestAnonymizeRotation(t *testing.T) {
+	messages, _ := anonymize(t, privateLog(), WithFakeOrigin(-33.86, 151.21, 90))
+
+	var track [][2]float64
+	for _, msg := range messages {
+		if msg.Name == "POS" {
+			track = append(track, [2]float64{msg.MustFloat64("Lat"), msg.MustFloat64("Lng")})
+		}
+	}
+	if math.Abs(track[0][0]+33.86) > 1e-7 || math.Abs(track[0][1]-151.21) > 1e-7 {
+		t.Errorf("expected the track to start at the fake origin, got %v", track[0])
+	}
+
+	// Heading north becomes heading east, 111 m per 0.001 degrees
+	for i := 1; i < len(track); i++ {
+		north := (track[i][0] - track[0][0]) * metresPerDegree
+		east := (track[i][1] - track[0][1]) * metresPerDegree * math.Cos(track[0][0]*math.Pi/180)
+		want := 0.001 * float64(i) * metresPerDegree
+		if math.Abs(north) > 0.05 || math.Abs(east-want) > 0.05 {
+			t.Errorf("point %d: expected %.2f m east, got %.2f m north and %.2f m east", i, want, north, east)
+		}
+	}
+}
+
+func TestAnonymizeDefaults(t *testing.T) {
+	messages, report := anonymize(t, privateLog(), WithRedaction(regexp.MustCompile(`u-(blox)`)))
+	for _, msg := range messages {
+		if msg.Name == "POS" {
+			if alt := msg.MustFloat64("Alt"); math.Abs(alt-180.5) < 99 || math.Abs(alt-180.5) > 1001 {
+				t.Errorf("expected the altitude to move by 100 to 1000 m, got %v", alt)
+			}
+		}
+		if msg.Name != "GPS" || msg.Fields["Lat"] == 0.0 {
+			continue
+		}
+		if math.Abs(msg.MustFloat64("Lat")-50.45) < 0.01 && math.Abs(msg.MustFloat64("Lng")-30.52) < 0.01 {
+			t.Errorf("expected the position to be moved, got %v", msg.Fields)
+		}
+	}
+	if !slices.Contains(report.Fields, AnonymizedField{"POS", "Alt", ChangeAltitude, 10}) {
+		t.Errorf("expected the altitude change to be reported, got %+v", report.Fields)
+	}
+	if report.Redactions[1].Text != "GPS 1: u-**** serial: *******" {
+		t.Errorf("expected the extra pattern to be masked, got %q", report.Redactions[1].Text)
+	}
+
+	parser, err := NewParserFromBytes(privateLog())
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if _, err := Anonymize(parser, &bytes.Buffer{}, WithFakeOrigin(89, 0, 0)); err == nil {
+		t.Error("expected an error for a fake origin near the pole")
+	}
+	stream := NewStreamParser(bytes.NewReader(privateLog()))
+	if _, err := Anonymize(stream, &bytes.Buffer{}); err != ErrNotSeekable {
+		t.Errorf("expected ErrNotSeekable, got %v", err)
+	}
+}
+
+func TestAnonymizeUnnamedCoordinates(t *testing.T) {
+	// L columns are positions whatever their names
+	wpt := &Schema{Type: 157, Length: 23, Name: "WPT", Format: "QLLL", Columns: "TimeUS,A,B,C"}
+	var l testLog
+	l.writePreamble()
+	l.writeFMT(wpt)
+	l.writeMessage(wpt.Type, uint64(1000), int32(50.45e7), int32(30.52e7), int32(50.46e7))
+
+	messages, report := anonymize(t, l.Bytes(), WithLocationOffset(1, -2))
+	msg := messages[len(messages)-1]
+	if math.Abs(msg.MustFloat64("A")-51.45) > 2e-7 || math.Abs(msg.MustFloat64("B")-28.52) > 2e-7 ||
+		math.Abs(msg.MustFloat64("C")-51.46) > 2e-7 {
+		t.Errorf("expected every L column to be moved, got %v", msg.Fields)
+	}
+	if len(report.Fields) != 3 || report.Fields[2] != (AnonymizedField{"WPT", "C", ChangeLocation, 1}) {
+		t.Errorf("unexpected report %+v", report.Fields)
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pryamcem/go-dataflash"
+)
+
+// goField describes how a format character is represented in a generated
+// struct and read from a FieldReader. %d in read is the field index.
+type goField struct {
+	typ  string
+	read string
+}
+
+// goFields maps format characters to the Go types DecodeMessageBody uses.
+var goFields = map[rune]goField{
+	'b': {"int8", "int8(r.Int64(%d))"},
+	'B': {"uint8", "uint8(r.Uint64(%d))"},
+	'M': {"uint8", "uint8(r.Uint64(%d))"},
+	'h': {"int16", "int16(r.Int64(%d))"},
+	'H': {"uint16", "uint16(r.Uint64(%d))"},
+	'i': {"int32", "int32(r.Int64(%d))"},
+	'I': {"uint32", "uint32(r.Uint64(%d))"},
+	'q': {"int64", "r.Int64(%d)"},
+	'Q': {"uint64", "r.Uint64(%d)"},
+	'f': {"float32", "float32(r.Float64(%d))"},
+	'g': {"float32", "float32(r.Float64(%d))"},
+	'd': {"float64", "r.Float64(%d)"},
+	'c': {"float64", "r.Float64(%d)"},
+	'C': {"float64", "r.Float64(%d)"},
+	'e': {"float64", "r.Float64(%d)"},
+	'E': {"float64", "r.Float64(%d)"},
+	'L': {"float64", "r.Float64(%d)"},
+	'n': {"string", "r.String(%d)"},
+	'N': {"string", "r.String(%d)"},
+	'Z': {"string", "r.String(%d)"},
+}
+
+// generate returns the gofmt-ed source of a file declaring a struct, a
+// Layout and a Decode method for each schema. units and mults name the unit
+// and multiplier identifiers for field comments.
+func generate(pkg, source string, schemas []*dataflash.Schema, units map[rune]string, mults map[rune]float64) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by dfgen from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	fmt.Fprintf(&b, "import (\n\t\"sync/atomic\"\n\n\t\"github.com/pryamcem/go-dataflash\"\n)\n\n")
+
+	typeNames := make([]string, len(schemas))
+	seen := map[string]bool{"Layouts": true}
+	for i, s := range schemas {
+		typeNames[i] = uniqueIdent(s.Name, seen)
+	}
+
+	fmt.Fprintf(&b, "// Layouts lists the layouts the types in this file were generated for,\n")
+	fmt.Fprintf(&b, "// for use with dataflash.CheckLayouts.\n")
+	fmt.Fprintf(&b, "var Layouts = []dataflash.Layout{\n")
+	for _, name := range typeNames {
+		fmt.Fprintf(&b, "\t%sLayout,\n", name)
+	}
+	fmt.Fprintf(&b, "}\n")
+
+	for i, s := range schemas {
+		if err := generateType(&b, typeNames[i], s, units, mults); err != nil {
+			return nil, err
+		}
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return src, nil
+}
+
+// generateType writes the declarations for schema s.
+func generateType(b *bytes.Buffer, name string, s *dataflash.Schema, units map[rune]string, mults map[rune]float64) error {
+	fields := s.Fields()
+	fieldNames := make([]string, len(fields))
+	seen := map[string]bool{"Decode": true}
+	for i, f := range fields {
+		if _, ok := goFields[f.Format]; !ok && f.Format != 'a' {
+			return fmt.Errorf("unsupported format character %q in %s", f.Format, s.Name)
+		}
+		fieldNames[i] = uniqueIdent(f.Name, seen)
+	}
+
+	fmt.Fprintf(b, "\n// %sType is the type ID of %s messages.\n", name, s.Name)
+	fmt.Fprintf(b, "const %sType = %d\n", name, s.Type)
+
+	fmt.Fprintf(b, "\n// %sLayout is the layout %s was generated for.\n", name, name)
+	fmt.Fprintf(b, "var %sLayout = dataflash.Layout{Type: %d, Name: %q, Format: %q, Columns: %q}\n",
+		name, s.Type, s.Name, s.Format, s.Columns)
+
+	fmt.Fprintf(b, "\n// checked%s is the last schema that passed the %sLayout check, so that\n", name, name)
+	fmt.Fprintf(b, "// Decode compares layouts once per FMT rather than once per message.\n")
+	fmt.Fprintf(b, "var checked%s atomic.Pointer[dataflash.Schema]\n", name)
+
+	fmt.Fprintf(b, "\n// %s holds the fields of %s messages.\n", name, s.Name)
+	fmt.Fprintf(b, "type %s struct {\n", name)
+	for i, f := range fields {
+		typ := "[32]int16"
+		if f.Format != 'a' {
+			typ = goFields[f.Format].typ
+		}
+		fmt.Fprintf(b, "\t%s %s", fieldNames[i], typ)
+		if comment := fieldComment(f, units, mults); comment != "" {
+			fmt.Fprintf(b, " // %s", comment)
+		}
+		fmt.Fprintf(b, "\n")
+	}
+	fmt.Fprintf(b, "}\n")
+
+	fmt.Fprintf(b, "\n// Decode reads the fields of the message r is positioned at. It returns\n")
+	fmt.Fprintf(b, "// an error wrapping dataflash.ErrLayoutMismatch if the message is not\n")
+	fmt.Fprintf(b, "// laid out as %sLayout.\n", name)
+	fmt.Fprintf(b, "func (m *%s) Decode(r *dataflash.FieldReader) error {\n", name)
+	fmt.Fprintf(b, "\tif s := r.Schema(); checked%s.Load() != s {\n", name)
+	fmt.Fprintf(b, "\t\tif err := %sLayout.Check(s); err != nil {\n\t\t\treturn err\n\t\t}\n", name)
+	fmt.Fprintf(b, "\t\tchecked%s.Store(s)\n\t}\n", name)
+	for i, f := range fields {
+		if f.Format == 'a' {
+			fmt.Fprintf(b, "\tr.Int16Array(%d, m.%s[:0])\n", i, fieldNames[i])
+			continue
+		}
+		fmt.Fprintf(b, "\tm.%s = %s\n", fieldNames[i], fmt.Sprintf(goFields[f.Format].read, i))
+	}
+	fmt.Fprintf(b, "\treturn nil\n}\n")
+	return nil
+}
+
+// fieldComment describes the unit of f and the multiplier GetScaled would
+// apply, if any.
+func fieldComment(f dataflash.FieldDef, units map[rune]string, mults map[rune]float64) string {
+	var parts []string
+	if unit := units[f.Unit]; unit != "" {
+		parts = append(parts, unit)
+	}
+	switch f.Format {
+	case 'c', 'C', 'e', 'E', 'L':
+		// Scaled when decoded
+	default:
+		if mult, ok := mults[f.Mult]; ok && mult != 0 && mult != 1 {
+			parts = append(parts, "scale "+strconv.FormatFloat(mult, 'g', -1, 64))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// uniqueIdent turns name into an exported Go identifier that is not yet in
+// seen, and adds it.
+func uniqueIdent(name string, seen map[string]bool) string {
+	ident := []rune(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, name))
+	if len(ident) == 0 || !unicode.IsLetter(ident[0]) {
+		ident = append([]rune("X"), ident...)
+	}
+	ident[0] = unicode.ToUpper(ident[0])
+
+	base := string(ident)
+	unique := base
+	for i := 2; seen[unique]; i++ {
+		unique = base + strconv.Itoa(i)
+	}
+	seen[unique] = true
+	return unique
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/pryamcem/go-dataflash"
+)
+
+func TestGenerate(t *testing.T) {
+	gps := &dataflash.Schema{Type: 131, Length: 24, Name: "GPS", Format: "QBLLe", Columns: "TimeUS,Status,Lat,Lng,Alt",
+		Units: "s-DUm", Mults: "F-GGB"}
+	isbd := &dataflash.Schema{Type: 140, Length: 76, Name: "ISBD", Format: "QMa", Columns: "TimeUS,decode,x"}
+	units := map[rune]string{'s': "s", 'D': "deglatitude", 'U': "deglongitude", 'm': "m"}
+	mults := map[rune]float64{'F': 1e-6, 'G': 1e-7, 'B': 1e-2, '-': 0}
+
+	src, err := generate("telemetry", "flight.bin", []*dataflash.Schema{gps, isbd}, units, mults)
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	got := string(src)
+
+	for _, want := range []string{
+		"// Code generated by dfgen from flight.bin. DO NOT EDIT.",
+		"package telemetry",
+		"const GPSType = 131",
+		`var GPSLayout = dataflash.Layout{Type: 131, Name: "GPS", Format: "QBLLe", Columns: "TimeUS,Status,Lat,Lng,Alt"}`,
+		"TimeUS uint64 // s, scale 1e-06",
+		"Status uint8\n",
+		"Lat    float64 // deglatitude\n",
+		"Alt    float64 // m\n",
+		"if s := r.Schema(); checkedGPS.Load() != s {",
+		"if err := GPSLayout.Check(s); err != nil {",
+		"checkedGPS.Store(s)",
+		"m.Lat = r.Float64(2)",
+		"m.Status = uint8(r.Uint64(1))",
+		// Columns clashing with the Decode method or unexported are renamed
+		"Decode2 uint8",
+		"X       [32]int16",
+		"r.Int16Array(2, m.X[:0])",
+		"Layouts = []dataflash.Layout{\n\tGPSLayout,\n\tISBDLayout,\n}",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected generated code to contain %q, got:\n%s", want, got)
+		}
+	}
+	typeCheck(t, src)
+}
+
+// typeCheck fails the test unless src is gofmt-ed Go that compiles against
+// the dataflash package.
+func typeCheck(t *testing.T, src []byte) {
+	t.Helper()
+	if formatted, err := format.Source(src); err != nil || string(formatted) != string(src) {
+		t.Fatalf("generated code is not gofmt-ed: %v\n%s", err, src)
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "generated.go", src, 0)
+	if err != nil {
+		t.Fatalf("failed to parse generated code: %v", err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check(file.Name.Name, fset, []*ast.File{file}, nil); err != nil {
+		t.Fatalf("generated code does not compile: %v\n%s", err, src)
+	}
+}
+
+func TestGenerateUnsupportedFormat(t *testing.T) {
+	bad := &dataflash.Schema{Type: 141, Length: 12, Name: "BAD", Format: "QX", Columns: "TimeUS,X"}
+	if _, err := generate("main", "log.bin", []*dataflash.Schema{bad}, nil, nil); err == nil {
+		t.Error("expected an error for an unknown format character")
+	}
+}
+
+func TestSelectSchemas(t *testing.T) {
+	all := map[uint8]*dataflash.Schema{
+		128: {Type: 128, Length: 89, Name: "FMT", Format: "BBnNZ", Columns: "Type,Length,Name,Format,Columns"},
+		130: {Type: 130, Length: 24, Name: "IMU", Format: "QBfff", Columns: "TimeUS,I,GyrX,GyrY,GyrZ"},
+		131: {Type: 131, Length: 24, Name: "GPS", Format: "QBLLe", Columns: "TimeUS,Status,Lat,Lng,Alt"},
+		132: {Type: 132, Length: 10, Name: "BAD", Format: "QB", Columns: "TimeUS"},
+	}
+
+	schemas, err := selectSchemas(all, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schemas) != 2 || schemas[0].Name != "GPS" || schemas[1].Name != "IMU" {
+		t.Errorf("expected GPS and IMU, got %v", schemas)
+	}
+
+	schemas, err = selectSchemas(all, []string{"IMU", "FMT"})
+	if err != nil || len(schemas) != 2 || schemas[0].Name != "FMT" {
+		t.Errorf("expected FMT and IMU, got %v, %v", schemas, err)
+	}
+	if _, err := selectSchemas(all, []string{"ATT"}); err == nil {
+		t.Error("expected an error for a missing message")
+	}
+	if _, err := selectSchemas(all, []string{"BAD"}); err == nil {
+		t.Error("expected an error for an invalid schema")
+	}
+
+	// A name under several type IDs can't be mapped to one type
+	all[140] = &dataflash.Schema{Type: 140, Length: 20, Name: "IMU", Format: "QBff", Columns: "TimeUS,I,AccX,AccY"}
+	for _, names := range [][]string{nil, {"IMU"}} {
+		_, err := selectSchemas(all, names)
+		if err == nil || err.Error() != `message "IMU" is defined under type IDs 130, 140` {
+			t.Errorf("%v: expected an error listing the type IDs, got %v", names, err)
+		}
+	}
+	if schemas, err := selectSchemas(all, []string{"GPS"}); err != nil || len(schemas) != 1 {
+		t.Errorf("expected GPS, got %v, %v", schemas, err)
+	}
+}
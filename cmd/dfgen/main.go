@@ -0,0 +1,137 @@
+// Command dfgen generates Go types for the messages of a DataFlash log.
+//
+// Usage:
+//
+//	dfgen [-pkg name] [-o file] [-types GPS,ATT] log.bin
+//
+// For each message type defined in the log, dfgen writes a struct with one
+// field per column, commented with its unit, and a Decode method that fills
+// it from a dataflash.FieldReader without reflection. The layout each type
+// was generated for is checked when decoding, and can be checked for a whole
+// log up front with dataflash.CheckLayouts. A message name the log defines
+// under several type IDs is rejected, as it can't map to one type. It is meant to be run from a
+// go:generate directive with a representative log:
+//
+//	//go:generate go run github.com/pryamcem/go-dataflash/cmd/dfgen -pkg telemetry -o messages.go -types GPS,ATT flight.bin
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pryamcem/go-dataflash"
+)
+
+func main() {
+	pkg := flag.String("pkg", "main", "package name of the generated file")
+	out := flag.String("o", "", "output file (default standard output)")
+	types := flag.String("types", "", "comma-separated message names (default all)")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: dfgen [-pkg name] [-o file] [-types GPS,ATT] <logfile.bin>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	parser, err := dataflash.NewParser(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer parser.Close()
+
+	var names []string
+	if *types != "" {
+		names = strings.Split(*types, ",")
+	}
+	schemas, err := selectSchemas(parser.GetSchemas(), names)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(*pkg, filepath.Base(flag.Arg(0)), schemas, parser.Units(), parser.Multipliers())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// selectSchemas returns the schemas with the given names, or all valid
+// schemas other than the schema messages themselves if names is empty,
+// ordered by name. A name defined under several type IDs can't be given a
+// single generated type, so selecting it is an error.
+func selectSchemas(all map[uint8]*dataflash.Schema, names []string) ([]*dataflash.Schema, error) {
+	byName := make(map[string][]*dataflash.Schema, len(all))
+	for _, typ := range slices.Sorted(maps.Keys(all)) {
+		s := all[typ]
+		byName[s.Name] = append(byName[s.Name], s)
+	}
+
+	var schemas []*dataflash.Schema
+	if len(names) == 0 {
+		for _, name := range slices.Sorted(maps.Keys(byName)) {
+			switch name {
+			case "FMT", "FMTU", "UNIT", "MULT":
+				continue
+			}
+			var valid []*dataflash.Schema
+			for _, s := range byName[name] {
+				if err := s.Validate(); err != nil {
+					log.Printf("skipping %s: %v", s.Name, err)
+					continue
+				}
+				valid = append(valid, s)
+			}
+			if len(valid) > 1 {
+				return nil, conflict(valid)
+			}
+			schemas = append(schemas, valid...)
+		}
+	} else {
+		for _, name := range names {
+			defs, ok := byName[strings.TrimSpace(name)]
+			if !ok {
+				return nil, fmt.Errorf("message %q not found in log", name)
+			}
+			if len(defs) > 1 {
+				return nil, conflict(defs)
+			}
+			if err := defs[0].Validate(); err != nil {
+				return nil, err
+			}
+			schemas = append(schemas, defs[0])
+		}
+	}
+
+	slices.SortFunc(schemas, func(a, b *dataflash.Schema) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return slices.CompactFunc(schemas, func(a, b *dataflash.Schema) bool {
+		return a == b
+	}), nil
+}
+
+// conflict returns the error for a message name defined by each of schemas,
+// which are ordered by type ID.
+func conflict(schemas []*dataflash.Schema) error {
+	ids := make([]string, len(schemas))
+	for i, s := range schemas {
+		ids[i] = strconv.Itoa(int(s.Type))
+	}
+	return fmt.Errorf("message %q is defined under type IDs %s", schemas[0].Name, strings.Join(ids, ", "))
+}
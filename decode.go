@@ -2,19 +2,27 @@ package dataflash
 
 import (
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"math"
 	"strings"
 )
 
+// ErrUnknownFormatChar is returned when a schema uses a format character
+// that is not part of the DataFlash format.
+var ErrUnknownFormatChar = errors.New("unknown format character")
+
 // formatSizes maps format characters to their byte sizes
 var formatSizes = map[rune]int{
 	'B': 1,  // uint8
+	'M': 1,  // uint8 flight mode
 	'b': 1,  // int8
 	'H': 2,  // uint16
 	'h': 2,  // int16
 	'I': 4,  // uint32
 	'i': 4,  // int32
 	'f': 4,  // float32
+	'g': 2,  // float16
 	'Q': 8,  // uint64
 	'q': 8,  // int64
 	'd': 8,  // float64
@@ -26,34 +34,29 @@ var formatSizes = map[rune]int{
 	'n': 4,  // char[4]
 	'N': 16, // char[16]
 	'Z': 64, // char[64]
+	'a': 64, // int16[32]
 }
 
 // DecodeMessageBody decodes a message body according to the provided schema.
 // Returns a map of field names to their decoded values.
 func DecodeMessageBody(body []byte, schema *Schema) (map[string]any, error) {
-	data := make(map[string]any)
-	columns := strings.Split(schema.Columns, ",")
-
-	offset := 0
-
-	for i, dataType := range schema.Format {
-		// Stop at null terminator
-		if dataType == 0 {
-			break
-		}
-
-		// Stop if no more columns
-		if i >= len(columns) {
-			break
-		}
+	fields := schema.Fields()
+	if schema.err != nil {
+		return nil, schema.err
+	}
+	if len(body) < schema.size {
+		return nil, fmt.Errorf("%s body has %d bytes, format needs %d", schema.Name, len(body), schema.size)
+	}
+	data := make(map[string]any, len(fields))
 
-		columnName := columns[i]
+	for _, field := range fields {
+		offset := field.Offset
 
 		// Decode based on format type
 		var value any
-		switch dataType {
+		switch field.Format {
 		// Unsigned integers
-		case 'B': // uint8
+		case 'B', 'M': // uint8, flight mode number
 			value = body[offset]
 		case 'H': // uint16
 			value = binary.LittleEndian.Uint16(body[offset:])
@@ -76,6 +79,8 @@ func DecodeMessageBody(body []byte, schema *Schema) (map[string]any, error) {
 		case 'f': // float32
 			bits := binary.LittleEndian.Uint32(body[offset:])
 			value = math.Float32frombits(bits)
+		case 'g': // float16
+			value = float16to32(binary.LittleEndian.Uint16(body[offset:]))
 		case 'd': // float64
 			bits := binary.LittleEndian.Uint64(body[offset:])
 			value = math.Float64frombits(bits)
@@ -105,17 +110,43 @@ func DecodeMessageBody(body []byte, schema *Schema) (map[string]any, error) {
 		case 'Z': // char[64]
 			value = strings.TrimRight(string(body[offset:offset+64]), "\x00")
 
-		default: // Unknown format type
-			offset += formatSizes[dataType]
+		// Arrays
+		case 'a': // int16[32]
+			values := make([]int16, 32)
+			for i := range values {
+				values[i] = int16(binary.LittleEndian.Uint16(body[offset+2*i:]))
+			}
+			value = values
+
+		default: // Rejected when the schema is compiled
 			continue
 		}
 
 		// Store the decoded value
-		data[columnName] = value
-
-		// Move offset forward by the size of this field
-		offset += formatSizes[dataType]
+		data[field.Name] = value
 	}
 
 	return data, nil
 }
+
+// float16to32 converts an IEEE 754 half-precision float to a float32.
+func float16to32(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h) & 0x3ff
+
+	switch {
+	case exp == 0x1f: // Inf or NaN
+		return math.Float32frombits(sign | 0xff<<23 | mant<<13)
+	case exp == 0 && mant == 0: // Zero
+		return math.Float32frombits(sign)
+	case exp == 0: // Subnormal, normalize it
+		exp = 1
+		for mant&0x400 == 0 {
+			mant <<= 1
+			exp--
+		}
+		mant &= 0x3ff
+	}
+	return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+}
@@ -1,7 +1,11 @@
 package dataflash
 
 import (
+	"encoding/binary"
+	"errors"
+	"math"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -213,3 +217,84 @@ func TestDecodeMessageBody_MoreFormatThanColumns(t *testing.T) {
 		t.Errorf("expected 2 fields, got %d", len(result))
 	}
 }
+
+func TestDecodeMessageBody_ArrayAndMode(t *testing.T) {
+	schema := &Schema{
+		Name:    "ISBD",
+		Format:  "Ma",
+		Columns: "Mode,Samples",
+		Length:  68, // 3 + 1 + 64
+	}
+
+	body := make([]byte, 65)
+	body[0] = 6 // RTL
+	expectedSamples := make([]int16, 32)
+	for i := range expectedSamples {
+		expectedSamples[i] = int16(i*100 - 1000)
+		binary.LittleEndian.PutUint16(body[1+2*i:], uint16(expectedSamples[i]))
+	}
+
+	result, err := DecodeMessageBody(body, schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]any{
+		"Mode":    uint8(6),
+		"Samples": expectedSamples,
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("got %v, want %v", result, expected)
+	}
+}
+
+func TestDecodeMessageBody_HalfFloat(t *testing.T) {
+	schema := &Schema{
+		Format:  "gggggg",
+		Columns: "One,MinusTwo,Half,Max,Tiny,Inf",
+		Length:  15, // 3 + 6 * 2
+	}
+
+	body := []byte{
+		0x00, 0x3C, // 1.0
+		0x00, 0xC0, // -2.0
+		0x00, 0x38, // 0.5
+		0xFF, 0x7B, // 65504, largest half
+		0x01, 0x00, // smallest subnormal, 2^-24
+		0x00, 0x7C, // +Inf
+	}
+
+	result, err := DecodeMessageBody(body, schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]any{
+		"One":      float32(1),
+		"MinusTwo": float32(-2),
+		"Half":     float32(0.5),
+		"Max":      float32(65504),
+		"Tiny":     float32(math.Ldexp(1, -24)),
+		"Inf":      float32(math.Inf(1)),
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("got %v, want %v", result, expected)
+	}
+}
+
+func TestDecodeMessageBody_UnknownFormatChar(t *testing.T) {
+	schema := &Schema{
+		Name:    "XYZ",
+		Format:  "BXH",
+		Columns: "A,B,C",
+		Length:  6,
+	}
+
+	_, err := DecodeMessageBody([]byte{1, 2, 3}, schema)
+	if !errors.Is(err, ErrUnknownFormatChar) {
+		t.Fatalf("expected ErrUnknownFormatChar, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "XYZ") || !strings.Contains(err.Error(), "'X'") {
+		t.Errorf("expected schema name and character in %q", err)
+	}
+}
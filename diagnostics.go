@@ -0,0 +1,119 @@
+package dataflash
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ParseMode selects how a parser reacts to corrupt data.
+type ParseMode int
+
+const (
+	// Tolerant skips over corrupt data and records what it skipped in the
+	// parser's Diagnostics. It is the default.
+	Tolerant ParseMode = iota
+	// Strict fails with an error wrapping ErrCorrupt at the first anomaly.
+	Strict
+)
+
+// ErrCorrupt is wrapped by the errors a Strict parser returns when the log
+// contains data it would otherwise skip.
+var ErrCorrupt = errors.New("corrupt log")
+
+// Diagnostics reports the anomalies a parser found in the log. Anomalies are
+// recorded once, however many times the log is read.
+type Diagnostics struct {
+	Resyncs        []Resync      // Places where the parser lost sync, in log order
+	BytesSkipped   int64         // Total bytes skipped while resyncing
+	UnknownTypes   map[uint8]int // Headers seen per type ID that has no schema
+	Truncated      bool          // The log ends inside a message
+	TruncatedAt    int64         // Offset of the truncated message
+	MalformedFMTU  []int64       // Offsets of FMTU messages that could not be applied
+	InvalidSchemas []*SchemaError
+}
+
+// Resync describes bytes skipped to find the next message header.
+type Resync struct {
+	Offset  int64 // Offset where the parser lost sync
+	Skipped int64 // Number of bytes skipped
+	Unknown bool  // Caused by an unknown type ID rather than an invalid header
+	Type    uint8 // The unknown type ID, if Unknown
+}
+
+// Diagnostics returns the anomalies found in the log so far. With the
+// default pre-scan this covers the whole log once the parser is created;
+// otherwise it covers what has been read.
+func (p *Parser) Diagnostics() Diagnostics {
+	return p.diag
+}
+
+// anomaly reports whether the anomaly at offset is new, remembering it so
+// that it is recorded once when the log is read again.
+func (p *Parser) anomaly(offset int64) bool {
+	if p.seen == nil {
+		p.seen = make(map[int64]bool)
+	}
+	if p.seen[offset] {
+		return false
+	}
+	p.seen[offset] = true
+	return true
+}
+
+// corrupt returns the error a Strict parser fails with, or nil when the
+// parser is tolerant.
+func (p *Parser) corrupt(offset int64, format string, args ...any) error {
+	if p.opts.mode != Strict {
+		return nil
+	}
+	return fmt.Errorf("%w at offset %d: %w", ErrCorrupt, offset, fmt.Errorf(format, args...))
+}
+
+// resync skips to the next message header after the one at the current
+// position, which is invalid or of unknown type msgType.
+func (p *Parser) resync(msgType uint8, unknown bool) error {
+	start := p.r.pos()
+	if unknown {
+		if err := p.corrupt(start, "unknown message type %d", msgType); err != nil {
+			return err
+		}
+	} else if err := p.corrupt(start, "invalid header"); err != nil {
+		return err
+	}
+
+	err := p.syncToNextHeader()
+	if p.anomaly(start) {
+		skipped := p.r.pos() - start
+		if unknown {
+			if p.diag.UnknownTypes == nil {
+				p.diag.UnknownTypes = make(map[uint8]int)
+			}
+			p.diag.UnknownTypes[msgType]++
+		}
+		p.diag.Resyncs = append(p.diag.Resyncs, Resync{
+			Offset:  start,
+			Skipped: skipped,
+			Unknown: unknown,
+			Type:    msgType,
+		})
+		p.diag.BytesSkipped += skipped
+	}
+	return err
+}
+
+// truncated records a message cut short by the end of the log when err is
+// io.ErrUnexpectedEOF, and returns the error to stop reading with.
+func (p *Parser) truncated(offset int64, err error) error {
+	if err != io.ErrUnexpectedEOF {
+		return err
+	}
+	if p.anomaly(offset) {
+		p.diag.Truncated = true
+		p.diag.TruncatedAt = offset
+	}
+	if strictErr := p.corrupt(offset, "truncated message"); strictErr != nil {
+		return strictErr
+	}
+	return err
+}
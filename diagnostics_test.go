@@ -0,0 +1,155 @@
+package dataflash
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// anomalousLog returns a log with one of each anomaly and the offsets at
+// which they occur.
+func anomalousLog() (data []byte, junk, unknown, fmtu, truncated int64) {
+	var l testLog
+	l.writePreamble()
+	l.writeIMU(1000)
+	junk = int64(l.Len())
+	l.Write([]byte{0x00, HEAD1, 0x01, 0x02, 0x03})
+	l.writeIMU(2000)
+	unknown = int64(l.Len())
+	l.writeMessage(200, uint64(2500))
+	l.writeIMU(3000)
+	fmtu = int64(l.Len())
+	l.writeFMTU(3500, 250, "s", "F")
+	l.writeIMU(4000)
+	truncated = int64(l.Len())
+	l.writeIMU(5000)
+	return l.Bytes()[:l.Len()-4], junk, unknown, fmtu, truncated
+}
+
+func TestDiagnostics(t *testing.T) {
+	data, junk, unknown, fmtu, truncated := anomalousLog()
+
+	for _, prescan := range []bool{true, false} {
+		parser, err := NewParserFromBytes(data, WithPrescan(prescan))
+		if err != nil {
+			t.Fatalf("failed to create parser: %v", err)
+		}
+
+		// Read the log twice; anomalies are only recorded once
+		for range 2 {
+			if err := parser.Rewind(); err != nil {
+				t.Fatalf("failed to rewind: %v", err)
+			}
+			if counts := countMessages(t, parser); counts["IMU"] != 4 {
+				t.Errorf("expected 4 IMU messages, got %d", counts["IMU"])
+			}
+		}
+
+		expected := Diagnostics{
+			Resyncs: []Resync{
+				{Offset: junk, Skipped: 5},
+				{Offset: unknown, Skipped: 11, Unknown: true, Type: 200},
+			},
+			BytesSkipped:  16,
+			UnknownTypes:  map[uint8]int{200: 1},
+			Truncated:     true,
+			TruncatedAt:   truncated,
+			MalformedFMTU: []int64{fmtu},
+		}
+		if got := parser.Diagnostics(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("prescan %v: got %+v, want %+v", prescan, got, expected)
+		}
+	}
+}
+
+func TestDiagnosticsTruncatedSkippedBody(t *testing.T) {
+	// Skipping the body of a truncated message seeks past the end of the
+	// file, which must still be detected
+	data := sampleLog(100)
+	parser, err := NewParserFromReader(bytes.NewReader(data[:len(data)-5]), WithBufferSize(minBufferSize))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if diag := parser.Diagnostics(); !diag.Truncated {
+		t.Errorf("expected the truncated message to be reported, got %+v", diag)
+	}
+}
+
+func TestDiagnosticsInvalidSchema(t *testing.T) {
+	var l testLog
+	l.writePreamble()
+	l.writeFMT(&Schema{Type: 140, Length: 10, Name: "BAD", Format: "QI", Columns: "TimeUS,V"})
+
+	parser, err := NewParserFromBytes(l.Bytes())
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	invalid := parser.Diagnostics().InvalidSchemas
+	if len(invalid) != 1 || invalid[0].Name != "BAD" {
+		t.Errorf("expected BAD to be reported as invalid, got %v", invalid)
+	}
+
+	_, err = NewParserFromBytes(l.Bytes(), WithParseMode(Strict))
+	var schemaErr *SchemaError
+	if !errors.Is(err, ErrCorrupt) || !errors.As(err, &schemaErr) {
+		t.Errorf("expected a corrupt log error wrapping *SchemaError, got %v", err)
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	// A clean log parses as usual
+	parser, err := NewParserFromBytes(sampleLog(10), WithParseMode(Strict))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if counts := countMessages(t, parser); counts["IMU"] != 10 {
+		t.Errorf("expected 10 IMU messages, got %d", counts["IMU"])
+	}
+
+	// The pre-scan fails at the first anomaly
+	data, junk, _, _, _ := anomalousLog()
+	_, err = NewParserFromBytes(data, WithParseMode(Strict))
+	if !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("expected ErrCorrupt, got %v", err)
+	}
+
+	// Without a pre-scan ReadMessage fails when it reaches it
+	parser, err = NewParserFromBytes(data, WithParseMode(Strict), WithPrescan(false))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	for {
+		msg, err := parser.ReadMessage()
+		if err == io.EOF {
+			t.Fatal("expected an error before the end of the log")
+		}
+		if err != nil {
+			if !errors.Is(err, ErrCorrupt) {
+				t.Fatalf("expected ErrCorrupt, got %v", err)
+			}
+			break
+		}
+		if msg.Offset >= junk {
+			t.Fatalf("read message at %d past the anomaly at %d", msg.Offset, junk)
+		}
+	}
+}
+
+func TestStrictModeTruncated(t *testing.T) {
+	data := sampleLog(10)
+	parser, err := NewParserFromBytes(data[:len(data)-5], WithParseMode(Strict), WithPrescan(false))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	var lastErr error
+	for {
+		if _, lastErr = parser.ReadMessage(); lastErr != nil {
+			break
+		}
+	}
+	if !errors.Is(lastErr, ErrCorrupt) {
+		t.Errorf("expected ErrCorrupt for a truncated message, got %v", lastErr)
+	}
+}
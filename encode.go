@@ -0,0 +1,317 @@
+package dataflash
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// EncodeMessageBody encodes field values into a message body according to
+// the provided schema. It is the inverse of DecodeMessageBody: decoding the
+// result yields the same fields, and encoding decoded fields reproduces the
+// original body. Values may be of any Go type that converts exactly, such as
+// int for a B field; scaled formats (c, C, e, E, L) take the scaled value and
+// round it to the nearest stored integer. Fields missing from fields are
+// encoded as zero.
+func EncodeMessageBody(fields map[string]any, schema *Schema) ([]byte, error) {
+	return appendMessageBody(nil, fields, schema)
+}
+
+// appendMessageBody appends the encoded body to dst.
+func appendMessageBody(dst []byte, fields map[string]any, schema *Schema) ([]byte, error) {
+	defs := schema.Fields()
+	if schema.err != nil {
+		return dst, schema.err
+	}
+
+	start := len(dst)
+	dst = append(dst, make([]byte, max(schema.size, int(schema.Length)-HeaderSize))...)
+	body := dst[start:]
+	for _, field := range defs {
+		value, ok := fields[field.Name]
+		if !ok {
+			continue
+		}
+		if err := encodeField(body[field.Offset:field.Offset+field.Size], field.Format, value); err != nil {
+			return dst[:start], fmt.Errorf("failed to encode %s.%s: %w", schema.Name, field.Name, err)
+		}
+	}
+	return dst, nil
+}
+
+// encodeField stores value in b, which has the size of the format.
+func encodeField(b []byte, format rune, value any) error {
+	switch format {
+	// Unsigned integers
+	case 'B', 'M':
+		v, err := unsignedValue(value, 8)
+		b[0] = byte(v)
+		return err
+	case 'H':
+		v, err := unsignedValue(value, 16)
+		binary.LittleEndian.PutUint16(b, uint16(v))
+		return err
+	case 'I':
+		v, err := unsignedValue(value, 32)
+		binary.LittleEndian.PutUint32(b, uint32(v))
+		return err
+	case 'Q':
+		v, err := unsignedValue(value, 64)
+		binary.LittleEndian.PutUint64(b, v)
+		return err
+
+	// Signed integers
+	case 'b':
+		v, err := signedValue(value, 8)
+		b[0] = byte(v)
+		return err
+	case 'h':
+		v, err := signedValue(value, 16)
+		binary.LittleEndian.PutUint16(b, uint16(v))
+		return err
+	case 'i':
+		v, err := signedValue(value, 32)
+		binary.LittleEndian.PutUint32(b, uint32(v))
+		return err
+	case 'q':
+		v, err := signedValue(value, 64)
+		binary.LittleEndian.PutUint64(b, uint64(v))
+		return err
+
+	// Floats
+	case 'f':
+		v, err := float32Value(value)
+		binary.LittleEndian.PutUint32(b, math.Float32bits(v))
+		return err
+	case 'g':
+		v, err := float32Value(value)
+		binary.LittleEndian.PutUint16(b, float32to16(v))
+		return err
+	case 'd':
+		v, err := toFloat64(value)
+		binary.LittleEndian.PutUint64(b, math.Float64bits(v))
+		return err
+
+	// Scaled values
+	case 'c':
+		v, err := scaledValue(value, 100)
+		if err == nil {
+			var raw int64
+			raw, err = signedValue(v, 16)
+			binary.LittleEndian.PutUint16(b, uint16(raw))
+		}
+		return err
+	case 'C':
+		v, err := scaledValue(value, 100)
+		if err == nil {
+			var raw uint64
+			raw, err = unsignedValue(v, 16)
+			binary.LittleEndian.PutUint16(b, uint16(raw))
+		}
+		return err
+	case 'e':
+		v, err := scaledValue(value, 100)
+		if err == nil {
+			var raw int64
+			raw, err = signedValue(v, 32)
+			binary.LittleEndian.PutUint32(b, uint32(raw))
+		}
+		return err
+	case 'E':
+		v, err := scaledValue(value, 100)
+		if err == nil {
+			var raw uint64
+			raw, err = unsignedValue(v, 32)
+			binary.LittleEndian.PutUint32(b, uint32(raw))
+		}
+		return err
+	case 'L':
+		v, err := scaledValue(value, 1e7)
+		if err == nil {
+			var raw int64
+			raw, err = signedValue(v, 32)
+			binary.LittleEndian.PutUint32(b, uint32(raw))
+		}
+		return err
+
+	// Strings
+	case 'n', 'N', 'Z':
+		var s string
+		switch v := value.(type) {
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			return fmt.Errorf("cannot encode %T as a string", value)
+		}
+		if len(s) > len(b) {
+			return fmt.Errorf("string of %d bytes does not fit in %d", len(s), len(b))
+		}
+		copy(b, s)
+		return nil
+
+	// Arrays
+	case 'a':
+		values, ok := value.([]int16)
+		if !ok {
+			return fmt.Errorf("cannot encode %T as []int16", value)
+		}
+		if len(values) > len(b)/2 {
+			return fmt.Errorf("array of %d values does not fit in %d", len(values), len(b)/2)
+		}
+		for i, v := range values {
+			binary.LittleEndian.PutUint16(b[2*i:], uint16(v))
+		}
+		return nil
+	}
+	return fmt.Errorf("%w %q", ErrUnknownFormatChar, format)
+}
+
+// signedValue converts an integer, a bool or an integral float to an int64
+// that fits in the given number of bits.
+func signedValue(value any, bits int) (int64, error) {
+	var v int64
+	switch x := value.(type) {
+	case int:
+		v = int64(x)
+	case int8:
+		v = int64(x)
+	case int16:
+		v = int64(x)
+	case int32:
+		v = int64(x)
+	case int64:
+		v = x
+	case uint, uint8, uint16, uint32, uint64:
+		u, _ := unsignedValue(x, 64)
+		if u > math.MaxInt64 {
+			return 0, fmt.Errorf("%v out of range for int%d", value, bits)
+		}
+		v = int64(u)
+	case float32, float64:
+		f, _ := toFloat64(x)
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, fmt.Errorf("%v is not an int%d", value, bits)
+		}
+		v = int64(f)
+	case bool:
+		if x {
+			v = 1
+		}
+	default:
+		return 0, fmt.Errorf("cannot encode %T as int%d", value, bits)
+	}
+
+	if bits < 64 && (v < -1<<(bits-1) || v >= 1<<(bits-1)) {
+		return 0, fmt.Errorf("%v out of range for int%d", value, bits)
+	}
+	return v, nil
+}
+
+// unsignedValue converts a non-negative integer, a bool or an integral float
+// to a uint64 that fits in the given number of bits.
+func unsignedValue(value any, bits int) (uint64, error) {
+	var v uint64
+	switch x := value.(type) {
+	case uint:
+		v = uint64(x)
+	case uint8:
+		v = uint64(x)
+	case uint16:
+		v = uint64(x)
+	case uint32:
+		v = uint64(x)
+	case uint64:
+		v = x
+	case int, int8, int16, int32, int64:
+		s, _ := signedValue(x, 64)
+		if s < 0 {
+			return 0, fmt.Errorf("%v out of range for uint%d", value, bits)
+		}
+		v = uint64(s)
+	case float32, float64:
+		f, _ := toFloat64(x)
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+			return 0, fmt.Errorf("%v is not a uint%d", value, bits)
+		}
+		v = uint64(f)
+	case bool:
+		if x {
+			v = 1
+		}
+	default:
+		return 0, fmt.Errorf("cannot encode %T as uint%d", value, bits)
+	}
+
+	if bits < 64 && v >= 1<<bits {
+		return 0, fmt.Errorf("%v out of range for uint%d", value, bits)
+	}
+	return v, nil
+}
+
+// float32Value converts a number to a float32. float64 values are rounded
+// to the nearest float32.
+func float32Value(value any) (float32, error) {
+	if v, ok := value.(float32); ok {
+		return v, nil
+	}
+	v, err := toFloat64(value)
+	return float32(v), err
+}
+
+// scaledValue returns value multiplied by scale and rounded to the nearest
+// integer, undoing the scaling DecodeMessageBody applies.
+func scaledValue(value any, scale float64) (float64, error) {
+	v, err := toFloat64(value)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("%v cannot be stored as a scaled integer", v)
+	}
+	return math.Round(v * scale), nil
+}
+
+// float32to16 converts a float32 to the nearest IEEE 754 half-precision
+// float, rounding ties to even. Values too large become infinity.
+func float32to16(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int32(bits>>23) & 0xff
+	mant := bits & 0x7fffff
+
+	if exp == 0xff { // Inf or NaN, keeping NaN a NaN
+		h := sign | 0x7c00 | uint16(mant>>13)
+		if mant != 0 && mant>>13 == 0 {
+			h |= 1
+		}
+		return h
+	}
+
+	e := exp - 127 + 15
+	switch {
+	case e >= 0x1f: // Overflow
+		return sign | 0x7c00
+	case e < -10: // Rounds to zero
+		return sign
+	case e <= 0: // Subnormal
+		mant |= 0x800000
+		shift := uint32(14 - e)
+		h := mant >> shift
+		rem := mant & (1<<shift - 1)
+		halfway := uint32(1) << (shift - 1)
+		if rem > halfway || rem == halfway && h&1 == 1 {
+			h++
+		}
+		return sign | uint16(h)
+	}
+
+	// Rounding up may carry into the exponent, up to infinity
+	h := uint32(e)<<10 | mant>>13
+	rem := mant & 0x1fff
+	if rem > 0x1000 || rem == 0x1000 && h&1 == 1 {
+		h++
+	}
+	return sign | uint16(h)
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"io"
 	"log"
 	"os"
 
@@ -31,11 +30,7 @@ func main() {
 
 	// Read messages
 	count := 0
-	for {
-		msg, err := parser.ReadMessage()
-		if err == io.EOF || err == io.ErrUnexpectedEOF {
-			break
-		}
+	for msg, err := range parser.All() {
 		if err != nil {
 			log.Fatalf("Error reading message: %v", err)
 		}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"io"
 	"log"
 	"os"
 
@@ -22,17 +21,9 @@ func main() {
 	defer parser.Close()
 
 	// Filter for GPS messages which have many fields with units
-	log.Println("Set filters")
-	if err := parser.SetFilter("GPS"); err != nil {
-		log.Fatal(err)
-	}
-
 	log.Println("10 Scaled Fields")
-	for count := range 10 {
-		msg, err := parser.ReadMessage()
-		if err == io.EOF || err == io.ErrUnexpectedEOF {
-			break
-		}
+	count := 0
+	for msg, err := range parser.Filtered("GPS") {
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -42,7 +33,8 @@ func main() {
 		scaled := msg.GetScaledFields()
 		fmt.Println(scaled)
 
-		if count > 10 {
+		count++
+		if count >= 10 {
 			break
 		}
 	}
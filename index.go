@@ -0,0 +1,209 @@
+package dataflash
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"io"
+	"sort"
+	"time"
+)
+
+// IndexEntry locates a message in the log.
+type IndexEntry struct {
+	Offset int64 // Byte offset of the message header
+	Type   uint8 // Message type ID
+	LineNo int64 // Message sequence number
+	TimeUS int64 // TimeUS of this message, or of the last message before it that has one
+}
+
+// Index records the position of every Interval-th message of a log, starting
+// with the first, so that the parser can seek without scanning from the start.
+// Size, ModTime and FMTHash identify the log the index was built from.
+type Index struct {
+	Interval int
+	Entries  []IndexEntry
+	Size     int64     // Size of the log in bytes
+	ModTime  time.Time // Modification time of the log file, zero if unknown
+	FMTHash  uint64    // FNV-1a hash of the log's FMT messages
+}
+
+// BuildIndex reads the whole log once and records an IndexEntry for every
+// message, or for every n-th message if WithIndexInterval was given.
+// Afterwards SeekLineNo, SeekTimeUS and GetSlice jump directly to the
+// requested position. The parser is rewound when done.
+// If the index was loaded from a sidecar file with the same interval,
+// BuildIndex only rewinds; a newly built index is saved to the sidecar file.
+// Returns ErrNotSeekable for stream parsers.
+func (p *Parser) BuildIndex() error {
+	return p.BuildIndexContext(context.Background())
+}
+
+// BuildIndexContext is like BuildIndex but stops with ctx.Err() once ctx is
+// done, leaving the parser without an index.
+func (p *Parser) BuildIndexContext(ctx context.Context) error {
+	if err := p.Rewind(); err != nil {
+		return err
+	}
+	if p.index != nil && p.index.Interval == p.opts.indexEvery {
+		return nil
+	}
+
+	index := &Index{Interval: p.opts.indexEvery}
+	hash := fnv.New64a()
+	var timeUS int64
+	for {
+		if err := checkContext(ctx, p.lineNo); err != nil {
+			return err
+		}
+
+		schema, body, err := p.skipMessage()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		p.reportProgress(StageIndex, p.lineNo, false)
+		if schema.Name == "FMT" {
+			hashFMT(hash, body)
+		}
+
+		if offset := schema.timeUSOffset(); offset >= 0 {
+			timeUS = int64(binary.LittleEndian.Uint64(body[offset:]))
+		}
+
+		if (p.lineNo-p.startLineNo-1)%int64(index.Interval) == 0 {
+			index.Entries = append(index.Entries, IndexEntry{
+				Offset: p.offset,
+				Type:   schema.Type,
+				LineNo: p.lineNo,
+				TimeUS: timeUS,
+			})
+		}
+	}
+
+	p.reportProgress(StageIndex, p.lineNo, true)
+	index.Size = p.r.pos()
+	index.FMTHash = hash.Sum64()
+	p.index = index
+	p.saveIndexCache()
+	return p.Rewind()
+}
+
+// Index returns the index built by BuildIndex, or nil.
+func (p *Parser) Index() *Index {
+	return p.index
+}
+
+// SeekLineNo positions the parser so that the next message read has the
+// given LineNo (subject to the filter). Without an index the log is scanned
+// from the start. Seeking past the last message positions the parser at the
+// end of the log.
+func (p *Parser) SeekLineNo(lineNo int64) error {
+	return p.seekLineNo(context.Background(), lineNo)
+}
+
+func (p *Parser) seekLineNo(ctx context.Context, lineNo int64) error {
+	if err := p.seekNear(lineNo); err != nil {
+		return err
+	}
+
+	for p.lineNo < lineNo-1 {
+		if err := checkContext(ctx, p.lineNo); err != nil {
+			return err
+		}
+		if _, _, err := p.skipMessage(); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// SeekTimeUS positions the parser at the first message whose TimeUS is at
+// least timeUS. Without an index the log is scanned from the start.
+// TimeUS is assumed to increase through the log, as it does within a boot.
+func (p *Parser) SeekTimeUS(timeUS int64) error {
+	return p.seekTimeUS(context.Background(), timeUS)
+}
+
+func (p *Parser) seekTimeUS(ctx context.Context, timeUS int64) error {
+	lineNo := int64(1)
+	if p.index != nil {
+		// Start from the last entry before the first one at or after timeUS
+		entries := p.index.Entries
+		i := sort.Search(len(entries), func(i int) bool {
+			return entries[i].TimeUS >= timeUS
+		})
+		if i > 0 {
+			lineNo = entries[i-1].LineNo
+		}
+	}
+	if err := p.seekNear(lineNo); err != nil {
+		return err
+	}
+
+	for {
+		if err := checkContext(ctx, p.lineNo); err != nil {
+			return err
+		}
+
+		schema, body, err := p.skipMessage()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if offset := schema.timeUSOffset(); offset >= 0 && int64(binary.LittleEndian.Uint64(body[offset:])) >= timeUS {
+			// Step back so the next read returns this message
+			p.lineNo--
+			return p.r.seek(p.offset)
+		}
+	}
+}
+
+// seekNear positions the parser at the closest indexed message at or before
+// lineNo, or at the start of the log without an index.
+func (p *Parser) seekNear(lineNo int64) error {
+	if p.index == nil || len(p.index.Entries) == 0 || lineNo <= p.index.Entries[0].LineNo {
+		return p.Rewind()
+	}
+
+	first := p.index.Entries[0].LineNo
+	i := min((lineNo-first)/int64(p.index.Interval), int64(len(p.index.Entries)-1))
+	entry := p.index.Entries[i]
+	if err := p.r.seek(entry.Offset); err != nil {
+		return err
+	}
+	p.lineNo = entry.LineNo - 1
+	p.progressAt = 0
+	return nil
+}
+
+// skipMessage reads the next message without decoding it, other than
+// absorbing schema messages when schemas are discovered lazily.
+// The returned body is only valid until the next read.
+func (p *Parser) skipMessage() (*Schema, []byte, error) {
+	_, schema, err := p.nextHeader()
+	if err != nil {
+		return nil, nil, err
+	}
+	p.lineNo++
+
+	body, err := p.r.next(int(schema.Length) - HeaderSize)
+	if err != nil {
+		return nil, nil, p.truncated(p.offset, err)
+	}
+
+	if p.lazy && isSchemaMessage(schema.Name) {
+		if err := p.absorb(schema, body); err != nil {
+			return nil, nil, err
+		}
+	}
+	return schema, body, nil
+}
@@ -0,0 +1,164 @@
+package dataflash
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestBuildIndex(t *testing.T) {
+	parser, err := NewParserFromBytes(sampleLog(1000))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if err := parser.BuildIndex(); err != nil {
+		t.Fatalf("failed to build index: %v", err)
+	}
+
+	index := parser.Index()
+	if len(index.Entries) != 6+1000+100 {
+		t.Fatalf("expected %d entries, got %d", 6+1000+100, len(index.Entries))
+	}
+
+	// Entries match the messages read sequentially
+	for i := range index.Entries {
+		msg, err := parser.ReadMessage()
+		if err != nil {
+			t.Fatalf("error reading message: %v", err)
+		}
+		entry := index.Entries[i]
+		if entry.Offset != msg.Offset || entry.LineNo != msg.LineNo || entry.Type != msg.Type {
+			t.Fatalf("entry %d = %+v, message at %d has LineNo %d type %d",
+				i, entry, msg.Offset, msg.LineNo, msg.Type)
+		}
+		if msg.TimeUS != 0 && entry.TimeUS != msg.TimeUS {
+			t.Errorf("entry %d TimeUS = %d, want %d", i, entry.TimeUS, msg.TimeUS)
+		}
+	}
+}
+
+func TestSeekLineNo(t *testing.T) {
+	for _, interval := range []int{1, 16} {
+		parser, err := NewParserFromBytes(sampleLog(1000), WithIndexInterval(interval))
+		if err != nil {
+			t.Fatalf("failed to create parser: %v", err)
+		}
+		if err := parser.BuildIndex(); err != nil {
+			t.Fatalf("failed to build index: %v", err)
+		}
+
+		for _, lineNo := range []int64{1, 2, 17, 500, 1106} {
+			if err := parser.SeekLineNo(lineNo); err != nil {
+				t.Fatalf("failed to seek to %d: %v", lineNo, err)
+			}
+			msg, err := parser.ReadMessage()
+			if err != nil {
+				t.Fatalf("error reading message: %v", err)
+			}
+			if msg.LineNo != lineNo {
+				t.Errorf("interval %d: seek to %d read LineNo %d", interval, lineNo, msg.LineNo)
+			}
+		}
+
+		// Seeking past the end leaves nothing to read
+		if err := parser.SeekLineNo(2000); err != nil {
+			t.Fatalf("failed to seek past end: %v", err)
+		}
+		if _, err := parser.ReadMessage(); err == nil {
+			t.Error("expected error reading past end")
+		}
+	}
+}
+
+func TestSeekTimeUS(t *testing.T) {
+	for _, interval := range []int{1, 16} {
+		parser, err := NewParserFromBytes(sampleLog(1000), WithIndexInterval(interval))
+		if err != nil {
+			t.Fatalf("failed to create parser: %v", err)
+		}
+		if err := parser.BuildIndex(); err != nil {
+			t.Fatalf("failed to build index: %v", err)
+		}
+
+		for _, timeUS := range []int64{0, 1000, 1500, 250000, 1000000} {
+			if err := parser.SeekTimeUS(timeUS); err != nil {
+				t.Fatalf("failed to seek to %d: %v", timeUS, err)
+			}
+			msg, err := parser.ReadMessage()
+			if err != nil {
+				t.Fatalf("error reading message: %v", err)
+			}
+			want := (timeUS + 999) / 1000 * 1000
+			if msg.TimeUS != want {
+				t.Errorf("interval %d: seek to %d read TimeUS %d, want %d", interval, timeUS, msg.TimeUS, want)
+			}
+		}
+	}
+}
+
+func TestGetSliceWithIndex(t *testing.T) {
+	data := sampleLog(1000)
+	indexed, err := NewParserFromBytes(data, WithIndexInterval(8))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if err := indexed.BuildIndex(); err != nil {
+		t.Fatalf("failed to build index: %v", err)
+	}
+	plain, err := NewParserFromReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	for _, p := range []*Parser{indexed, plain} {
+		if err := p.SetFilter("GPS", "IMU"); err != nil {
+			t.Fatalf("failed to set filter: %v", err)
+		}
+	}
+
+	tests := []struct {
+		start, end int64
+		sliceType  SliceType
+	}{
+		{100, 150, SliceByLineNo},
+		{1090, 1200, SliceByLineNo},
+		{250500, 300000, SliceByTimeUS},
+		{990000, 2000000, SliceByTimeUS},
+	}
+	for _, tt := range tests {
+		want, err := plain.GetSlice(tt.start, tt.end, tt.sliceType)
+		if err != nil {
+			t.Fatalf("error getting slice: %v", err)
+		}
+		got, err := indexed.GetSlice(tt.start, tt.end, tt.sliceType)
+		if err != nil {
+			t.Fatalf("error getting indexed slice: %v", err)
+		}
+		if len(want) == 0 || !reflect.DeepEqual(got, want) {
+			t.Errorf("%s [%d, %d): indexed slice has %d messages, want %d",
+				tt.sliceType, tt.start, tt.end, len(got), len(want))
+		}
+	}
+}
+
+func TestBuildIndexStream(t *testing.T) {
+	parser := NewStreamParser(bytes.NewReader(sampleLog(10)))
+	if err := parser.BuildIndex(); err != ErrNotSeekable {
+		t.Errorf("expected ErrNotSeekable, got %v", err)
+	}
+}
+
+func BenchmarkGetSliceIndexed(b *testing.B) {
+	parser, err := NewParserFromBytes(sampleLog(100000))
+	if err != nil {
+		b.Fatalf("failed to create parser: %v", err)
+	}
+	if err := parser.BuildIndex(); err != nil {
+		b.Fatalf("failed to build index: %v", err)
+	}
+	for b.Loop() {
+		if _, err := parser.GetSlice(100000, 100010, SliceByLineNo); err != nil {
+			b.Fatalf("error getting slice: %v", err)
+		}
+	}
+}
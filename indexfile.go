@@ -0,0 +1,221 @@
+package dataflash
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"time"
+)
+
+// Sidecar index file format. All integers are little-endian:
+//
+//	magic    [4]byte "DFIX"
+//	version  uint16
+//	interval uint32
+//	size     int64  log size in bytes
+//	modTime  int64  log modification time in Unix nanoseconds, 0 if unknown
+//	fmtHash  uint64
+//	count    uint64 number of entries
+//
+// followed by count entries, each encoded relative to the previous one as
+// uvarint(offset delta), type byte, varint(TimeUS delta).
+// LineNo is implied by the entry position and the interval.
+const (
+	indexMagic   = "DFIX"
+	indexVersion = 1
+)
+
+// indexHeader is the fixed-size header of a sidecar index file.
+type indexHeader struct {
+	Magic    [4]byte
+	Version  uint16
+	Interval uint32
+	Size     int64
+	ModTime  int64
+	FMTHash  uint64
+	Count    uint64
+}
+
+// WriteTo writes the index to w in the sidecar file format.
+func (idx *Index) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+
+	header := indexHeader{
+		Version:  indexVersion,
+		Interval: uint32(idx.Interval),
+		Size:     idx.Size,
+		FMTHash:  idx.FMTHash,
+		Count:    uint64(len(idx.Entries)),
+	}
+	copy(header.Magic[:], indexMagic)
+	if !idx.ModTime.IsZero() {
+		header.ModTime = idx.ModTime.UnixNano()
+	}
+	if err := binary.Write(bw, binary.LittleEndian, &header); err != nil {
+		return 0, err
+	}
+	n := int64(binary.Size(header))
+
+	buf := make([]byte, 2*binary.MaxVarintLen64+1)
+	var prev IndexEntry
+	for _, entry := range idx.Entries {
+		size := binary.PutUvarint(buf, uint64(entry.Offset-prev.Offset))
+		buf[size] = entry.Type
+		size++
+		size += binary.PutVarint(buf[size:], entry.TimeUS-prev.TimeUS)
+		if _, err := bw.Write(buf[:size]); err != nil {
+			return n, err
+		}
+		n += int64(size)
+		prev = entry
+	}
+
+	return n, bw.Flush()
+}
+
+// ReadIndex reads an index in the sidecar file format from r. size is the
+// size of the log the index describes; an index written for a log of another
+// size is rejected before its entries are read.
+func ReadIndex(r io.Reader, size int64) (*Index, error) {
+	br := bufio.NewReader(r)
+
+	var header indexHeader
+	if err := binary.Read(br, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("reading index header: %w", err)
+	}
+	if string(header.Magic[:]) != indexMagic {
+		return nil, errors.New("not an index file")
+	}
+	if header.Version != indexVersion {
+		return nil, fmt.Errorf("unsupported index version %d", header.Version)
+	}
+	if header.Interval == 0 {
+		return nil, errors.New("invalid index interval 0")
+	}
+	if header.Size != size {
+		return nil, fmt.Errorf("index is for a log of %d bytes, not %d", header.Size, size)
+	}
+
+	idx := &Index{
+		Interval: int(header.Interval),
+		Size:     header.Size,
+		FMTHash:  header.FMTHash,
+	}
+	if header.ModTime != 0 {
+		idx.ModTime = time.Unix(0, header.ModTime)
+	}
+
+	// Entries cannot be larger than the log they describe. The count is
+	// still untrusted, so entries are appended as they are read rather than
+	// allocated up front.
+	if header.Count > uint64(size)/HeaderSize+1 {
+		return nil, fmt.Errorf("invalid index entry count %d", header.Count)
+	}
+	var prev IndexEntry
+	for i := range int(header.Count) {
+		offsetDelta, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("reading index entry %d: %w", i, err)
+		}
+		typ, err := br.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("reading index entry %d: %w", i, err)
+		}
+		timeUSDelta, err := binary.ReadVarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("reading index entry %d: %w", i, err)
+		}
+
+		entry := IndexEntry{
+			Offset: prev.Offset + int64(offsetDelta),
+			Type:   typ,
+			LineNo: int64(i)*int64(idx.Interval) + 1,
+			TimeUS: prev.TimeUS + timeUSDelta,
+		}
+		idx.Entries = append(idx.Entries, entry)
+		prev = entry
+	}
+
+	return idx, nil
+}
+
+// useIndexCache loads the sidecar index of the log file filename if it
+// matches the log. A stale sidecar is rebuilt and rewritten; a missing one is
+// left for BuildIndex to create.
+func (p *Parser) useIndexCache(ctx context.Context, filename string) error {
+	if !p.opts.indexCache || p.lazy {
+		return nil
+	}
+
+	info, err := os.Stat(filename)
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+	p.filename = filename
+
+	file, err := os.Open(indexPath(filename))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err == nil {
+		// Any error reading the sidecar means it is stale or corrupt
+		idx, readErr := ReadIndex(file, info.Size())
+		file.Close()
+		if readErr == nil && idx.ModTime.Equal(info.ModTime()) && idx.FMTHash == p.fmtHash {
+			p.index = idx
+			return nil
+		}
+	}
+
+	return p.BuildIndexContext(ctx)
+}
+
+// saveIndexCache writes the index to the sidecar file, if caching is enabled.
+// The cache is best effort: failing to write it does not affect parsing.
+func (p *Parser) saveIndexCache() {
+	if p.filename == "" {
+		return
+	}
+
+	info, err := os.Stat(p.filename)
+	if err != nil || info.Size() != p.index.Size {
+		return
+	}
+	p.index.ModTime = info.ModTime()
+
+	// Write to a temporary file first so readers never see a partial index
+	path := indexPath(p.filename)
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return
+	}
+	if _, err := p.index.WriteTo(file); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmp)
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+	}
+}
+
+// indexPath returns the sidecar index file name for the log file filename.
+func indexPath(filename string) string {
+	return filename + ".idx"
+}
+
+// hashFMT adds a FMT message with the given body to h.
+func hashFMT(h hash.Hash64, body []byte) {
+	h.Write([]byte{HEAD1, HEAD2, FMTType})
+	h.Write(body)
+}
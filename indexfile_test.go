@@ -0,0 +1,165 @@
+package dataflash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIndexRoundTrip(t *testing.T) {
+	parser, err := NewParserFromBytes(sampleLog(1000), WithIndexInterval(4))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if err := parser.BuildIndex(); err != nil {
+		t.Fatalf("failed to build index: %v", err)
+	}
+	want := parser.Index()
+	want.ModTime = time.Unix(1700000000, 123)
+
+	var buf bytes.Buffer
+	n, err := want.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("failed to write index: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo returned %d, wrote %d bytes", n, buf.Len())
+	}
+
+	data := buf.Bytes()
+	got, err := ReadIndex(bytes.NewReader(data), want.Size)
+	if err != nil {
+		t.Fatalf("failed to read index: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, err := ReadIndex(bytes.NewReader([]byte("not an index")), want.Size); err == nil {
+		t.Error("expected error reading invalid index")
+	}
+	if _, err := ReadIndex(bytes.NewReader(data), want.Size+1); err == nil {
+		t.Error("expected error reading the index of another log")
+	}
+}
+
+func TestIndexCacheCorrupt(t *testing.T) {
+	data := sampleLog(1000)
+	filename := writeTempLog(t, data)
+
+	parser, err := NewParser(filename)
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if err := parser.BuildIndex(); err != nil {
+		t.Fatalf("failed to build index: %v", err)
+	}
+	built := parser.Index()
+	parser.Close()
+	sidecar, err := os.ReadFile(indexPath(filename))
+	if err != nil {
+		t.Fatalf("failed to read sidecar: %v", err)
+	}
+
+	header := indexHeader{Version: indexVersion, Interval: 100, Size: 1 << 50, Count: 1 << 45}
+	copy(header.Magic[:], indexMagic)
+	var huge bytes.Buffer
+	binary.Write(&huge, binary.LittleEndian, &header)
+	header.Size = int64(len(data))
+	header.Count = uint64(len(data)) / HeaderSize
+	var count bytes.Buffer
+	binary.Write(&count, binary.LittleEndian, &header)
+
+	tests := map[string][]byte{
+		"truncated":  sidecar[:len(sidecar)/2],
+		"huge size":  huge.Bytes(),
+		"huge count": count.Bytes(),
+	}
+	for name, corrupt := range tests {
+		if err := os.WriteFile(indexPath(filename), corrupt, 0o644); err != nil {
+			t.Fatalf("failed to write sidecar: %v", err)
+		}
+		parser, err := NewParser(filename)
+		if err != nil {
+			t.Errorf("%s: failed to create parser: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(parser.Index(), built) {
+			t.Errorf("%s: expected the index to be rebuilt", name)
+		}
+		parser.Close()
+	}
+}
+
+func TestIndexCache(t *testing.T) {
+	filename := writeTempLog(t, sampleLog(1000))
+
+	parser, err := NewParser(filename)
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if parser.Index() != nil {
+		t.Fatal("expected no index before BuildIndex")
+	}
+	if err := parser.BuildIndex(); err != nil {
+		t.Fatalf("failed to build index: %v", err)
+	}
+	built := parser.Index()
+	parser.Close()
+
+	if _, err := os.Stat(indexPath(filename)); err != nil {
+		t.Fatalf("sidecar index not written: %v", err)
+	}
+
+	// A new parser picks up the sidecar
+	parser, err = NewParser(filename)
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if !reflect.DeepEqual(parser.Index(), built) {
+		t.Errorf("loaded index differs from built index")
+	}
+	messages, err := parser.GetSlice(500, 510, SliceByLineNo)
+	if err != nil || len(messages) != 10 || messages[0].LineNo != 500 {
+		t.Errorf("unexpected slice using loaded index: %d messages, %v", len(messages), err)
+	}
+	parser.Close()
+
+	// Caching can be disabled
+	parser, err = NewParserWithOptions(filename, WithIndexCache(false))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if parser.Index() != nil {
+		t.Error("expected no index with caching disabled")
+	}
+	parser.Close()
+
+	// A changed log invalidates the sidecar, which is rebuilt
+	if err := os.WriteFile(filename, sampleLog(2000), 0o644); err != nil {
+		t.Fatalf("failed to rewrite log: %v", err)
+	}
+	parser, err = NewParser(filename)
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	if parser.Index() == nil || len(parser.Index().Entries) != 6+2000+200 {
+		t.Fatalf("expected rebuilt index for changed log")
+	}
+	reloaded, err := os.Open(indexPath(filename))
+	if err != nil {
+		t.Fatalf("failed to open sidecar: %v", err)
+	}
+	defer reloaded.Close()
+	idx, err := ReadIndex(reloaded, parser.Index().Size)
+	if err != nil {
+		t.Fatalf("failed to read sidecar: %v", err)
+	}
+	if len(idx.Entries) != len(parser.Index().Entries) {
+		t.Errorf("sidecar has %d entries, want %d", len(idx.Entries), len(parser.Index().Entries))
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"go/format"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// xmlDefinitions is a message definition file.
+type xmlDefinitions struct {
+	Includes []string     `xml:"include"`
+	Messages []xmlMessage `xml:"messages>message"`
+}
+
+// xmlMessage is a message element. Its children are kept in order, as an
+// <extensions/> element marks the fields after it as extensions.
+type xmlMessage struct {
+	ID       uint32       `xml:"id,attr"`
+	Name     string       `xml:"name,attr"`
+	Children []xmlElement `xml:",any"`
+}
+
+// xmlElement is a child of a message element, such as a field.
+type xmlElement struct {
+	XMLName xml.Name
+	Type    string `xml:"type,attr"`
+	Name    string `xml:"name,attr"`
+}
+
+// field is a field of a message.
+type field struct {
+	Name      string
+	Type      string // C type without the array length
+	ArrayLen  int
+	Extension bool
+}
+
+// message is a message definition.
+type message struct {
+	ID     uint32
+	Name   string
+	Fields []field // In declaration order
+	source string  // File the message is defined in
+}
+
+// typeSizes lists the field types MAVLink defines.
+var typeSizes = map[string]int{
+	"char": 1, "uint8_t": 1, "int8_t": 1, "uint16_t": 2, "int16_t": 2,
+	"uint32_t": 4, "int32_t": 4, "float": 4, "uint64_t": 8, "int64_t": 8, "double": 8,
+}
+
+// fieldType matches a field type: a C type, an optional array length, or
+// the uint8_t_mavlink_version type of HEARTBEAT.mavlink_version.
+var fieldType = regexp.MustCompile(`^([a-z0-9]+?(?:_t)?)(?:_mavlink_version)?(?:\[(\d+)\])?$`)
+
+// load reads the definition file name with read and returns the messages
+// of it and the files it includes, ordered by ID.
+func load(name string, read func(name string) ([]byte, error)) ([]*message, error) {
+	byID := make(map[uint32]*message)
+	loaded := make(map[string]bool)
+
+	var loadFile func(name string) error
+	loadFile = func(name string) error {
+		if loaded[name] {
+			return nil
+		}
+		loaded[name] = true
+
+		data, err := read(name)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		var defs xmlDefinitions
+		if err := xml.Unmarshal(data, &defs); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		for _, include := range defs.Includes {
+			if err := loadFile(include); err != nil {
+				return err
+			}
+		}
+
+		for _, x := range defs.Messages {
+			m, err := parseMessage(x)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", name, err)
+			}
+			m.source = name
+			if prev, ok := byID[m.ID]; ok {
+				if prev.Name != m.Name || !slices.Equal(prev.Fields, m.Fields) {
+					return fmt.Errorf("message %d is defined as %s in %s and as %s in %s",
+						m.ID, prev.Name, prev.source, m.Name, name)
+				}
+				continue
+			}
+			byID[m.ID] = m
+		}
+		return nil
+	}
+	if err := loadFile(name); err != nil {
+		return nil, err
+	}
+
+	messages := make([]*message, 0, len(byID))
+	for _, m := range byID {
+		messages = append(messages, m)
+	}
+	slices.SortFunc(messages, func(a, b *message) int {
+		return int(a.ID) - int(b.ID)
+	})
+	return messages, nil
+}
+
+// parseMessage converts a message element.
+func parseMessage(x xmlMessage) (*message, error) {
+	if x.Name == "" || x.ID > 1<<24-1 {
+		return nil, fmt.Errorf("invalid message %q with ID %d", x.Name, x.ID)
+	}
+	m := &message{ID: x.ID, Name: x.Name}
+	extension := false
+	for _, child := range x.Children {
+		switch child.XMLName.Local {
+		case "extensions":
+			extension = true
+		case "field":
+			match := fieldType.FindStringSubmatch(child.Type)
+			if match == nil || typeSizes[match[1]] == 0 {
+				return nil, fmt.Errorf("unsupported type %q of %s.%s", child.Type, x.Name, child.Name)
+			}
+			f := field{Name: child.Name, Type: match[1], Extension: extension}
+			if match[2] != "" {
+				f.ArrayLen, _ = strconv.Atoi(match[2])
+			}
+			m.Fields = append(m.Fields, f)
+		}
+	}
+	if len(m.Fields) == 0 {
+		return nil, fmt.Errorf("message %s has no fields", x.Name)
+	}
+	return m, nil
+}
+
+// generate returns the gofmt-ed source of the mavlinkDialect table holding
+// messages, read from the definition file source.
+func generate(source string, messages []*message) ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by mavgen from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&b, "package dataflash\n\n")
+	fmt.Fprintf(&b, "// mavlinkDialect holds the messages of %s and the files it\n", source)
+	fmt.Fprintf(&b, "// includes, in message ID order. Frames of other messages are skipped.\n")
+	fmt.Fprintf(&b, "var mavlinkDialect = []*mavlinkMessage{\n")
+	for _, m := range messages {
+		fmt.Fprintf(&b, "{ID: %d, Name: %q, Fields: []mavlinkField{\n", m.ID, m.Name)
+		for _, f := range m.Fields {
+			fmt.Fprintf(&b, "{Name: %q, Type: %q", f.Name, f.Type)
+			if f.ArrayLen > 0 {
+				fmt.Fprintf(&b, ", ArrayLen: %d", f.ArrayLen)
+			}
+			if f.Extension {
+				fmt.Fprintf(&b, ", Extension: true")
+			}
+			fmt.Fprintf(&b, "},\n")
+		}
+		fmt.Fprintf(&b, "}},\n")
+	}
+	fmt.Fprintf(&b, "}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return src, nil
+}
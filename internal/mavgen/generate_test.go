@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testDefinitions returns a read function over XML files held in memory.
+func testDefinitions(files map[string]string) func(name string) ([]byte, error) {
+	return func(name string) ([]byte, error) {
+		data, ok := files[name]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(data), nil
+	}
+}
+
+func TestLoad(t *testing.T) {
+	read := testDefinitions(map[string]string{
+		"minimal.xml": `<mavlink><messages>
+			<message id="0" name="HEARTBEAT">
+				<description>The heartbeat.</description>
+				<field type="uint8_t" name="type" enum="MAV_TYPE">Vehicle type</field>
+				<field type="uint8_t_mavlink_version" name="mavlink_version">Version</field>
+			</message>
+		</messages></mavlink>`,
+		"dialect.xml": `<mavlink>
+			<include>minimal.xml</include>
+			<include>minimal.xml</include>
+			<enums><enum name="MAV_TYPE"/></enums>
+			<messages>
+				<message id="253" name="STATUSTEXT">
+					<field type="uint8_t" name="severity"/>
+					<field type="char[50]" name="text"/>
+					<extensions/>
+					<field type="uint16_t" name="id"/>
+				</message>
+				<message id="65789" name="LARGE_ID">
+					<wip/>
+					<field type="double" name="value"/>
+				</message>
+			</messages>
+		</mavlink>`,
+	})
+
+	messages, err := load("dialect.xml", read)
+	if err != nil {
+		t.Fatalf("failed to load: %v", err)
+	}
+	var names []string
+	for _, m := range messages {
+		names = append(names, m.Name)
+	}
+	if !slices.Equal(names, []string{"HEARTBEAT", "STATUSTEXT", "LARGE_ID"}) {
+		t.Fatalf("expected messages in ID order, got %v", names)
+	}
+	expected := []field{{Name: "type", Type: "uint8_t"}, {Name: "mavlink_version", Type: "uint8_t"}}
+	if !slices.Equal(messages[0].Fields, expected) {
+		t.Errorf("expected %+v, got %+v", expected, messages[0].Fields)
+	}
+	expected = []field{
+		{Name: "severity", Type: "uint8_t"},
+		{Name: "text", Type: "char", ArrayLen: 50},
+		{Name: "id", Type: "uint16_t", Extension: true},
+	}
+	if !slices.Equal(messages[1].Fields, expected) {
+		t.Errorf("expected %+v, got %+v", expected, messages[1].Fields)
+	}
+
+	src, err := generate("dialect.xml", messages)
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	for _, want := range []string{
+		"// Code generated by mavgen from dialect.xml. DO NOT EDIT.",
+		`{Name: "text", Type: "char", ArrayLen: 50},`,
+		`{Name: "id", Type: "uint16_t", Extension: true},`,
+		`{ID: 65789, Name: "LARGE_ID", Fields: []mavlinkField{`,
+	} {
+		if !bytes.Contains(src, []byte(want)) {
+			t.Errorf("expected generated code to contain %q, got:\n%s", want, src)
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := map[string]string{
+		"conflict": `<mavlink><include>minimal.xml</include><messages>
+			<message id="0" name="OTHER"><field type="uint8_t" name="x"/></message>
+		</messages></mavlink>`,
+		"type": `<mavlink><messages>
+			<message id="1" name="BAD"><field type="uint128_t" name="x"/></message>
+		</messages></mavlink>`,
+		"include": `<mavlink><include>missing.xml</include></mavlink>`,
+	}
+	for name, dialect := range tests {
+		read := testDefinitions(map[string]string{
+			"minimal.xml": `<mavlink><messages>
+				<message id="0" name="HEARTBEAT"><field type="uint8_t" name="type"/></message>
+			</messages></mavlink>`,
+			"dialect.xml": dialect,
+		})
+		if _, err := load("dialect.xml", read); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestFetch(t *testing.T) {
+	const commit = "0123456789abcdef0123456789abcdef01234567"
+	files := map[string]string{
+		"/" + commit + "/COPYING": "licence",
+		"/" + commit + "/message_definitions/v1.0/minimal.xml": `<mavlink><messages>
+			<message id="0" name="HEARTBEAT"><field type="uint8_t" name="type"/></message>
+		</messages></mavlink>`,
+		"/" + commit + "/message_definitions/v1.0/dialect.xml": `<mavlink><include>minimal.xml</include></mavlink>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(data))
+	}))
+	defer server.Close()
+
+	// Files left from the previous definitions are replaced
+	dir := t.TempDir()
+	for _, name := range []string{"old.xml", "LICENSE.gomavlib", "README.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("old"), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	messages, err := fetch(server.URL+"/"+commit+"/", commit, dir, "dialect.xml")
+	if err != nil {
+		t.Fatalf("failed to fetch: %v", err)
+	}
+	if len(messages) != 1 || messages[0].Name != "HEARTBEAT" {
+		t.Errorf("unexpected messages %+v", messages)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to list definitions: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if !slices.Equal(names, []string{"COPYING", "README.md", "dialect.xml", "minimal.xml"}) {
+		t.Errorf("unexpected files %v", names)
+	}
+	if readme, _ := os.ReadFile(filepath.Join(dir, "README.md")); !strings.Contains(string(readme), commit) {
+		t.Errorf("expected the README to record the commit, got:\n%s", readme)
+	}
+
+	if _, err := fetch(server.URL+"/missing/", "missing", t.TempDir(), "dialect.xml"); err == nil {
+		t.Error("expected an error for a missing commit")
+	}
+}
+
+func TestGeneratedDialectIsCurrent(t *testing.T) {
+	read := func(name string) ([]byte, error) {
+		return os.ReadFile(filepath.Join("message_definitions", name))
+	}
+	messages, err := load("ardupilotmega.xml", read)
+	if err != nil {
+		t.Fatalf("failed to load vendored definitions: %v", err)
+	}
+	src, err := generate("ardupilotmega.xml", messages)
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	current, err := os.ReadFile("../../mavlink_dialect.go")
+	if err != nil {
+		t.Fatalf("failed to read generated dialect: %v", err)
+	}
+	if !bytes.Equal(src, current) {
+		t.Error("mavlink_dialect.go is out of date, run go generate in the module root")
+	}
+}
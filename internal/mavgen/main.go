@@ -0,0 +1,149 @@
+// Command mavgen generates the MAVLink dialect table that TlogParser decodes
+// from the message definition XML of the mavlink project.
+//
+// Usage:
+//
+//	mavgen [-defs dir] [-o file] [-fetch commit] dialect.xml
+//
+// mavgen reads dialect.xml from dir, follows its includes, and writes every
+// message they define as the mavlinkDialect table of package dataflash.
+// With -fetch it first downloads dialect.xml and the files it includes at
+// the given commit of github.com/mavlink/mavlink into dir, replacing the
+// vendored copies, along with the upstream licence, and records the commit
+// in dir/README.md. It is run from the go:generate directive in mavlink.go:
+//
+//	//go:generate go run ./internal/mavgen -defs internal/mavgen/message_definitions -o mavlink_dialect.go ardupilotmega.xml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// upstream is the location of the files of the mavlink repository, formatted
+// with a commit.
+const upstream = "https://raw.githubusercontent.com/mavlink/mavlink/%s/"
+
+// commitHash matches a full git commit hash, which pins the fetched files.
+var commitHash = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+func main() {
+	defs := flag.String("defs", "message_definitions", "directory of the message definition XML")
+	out := flag.String("o", "", "output file (default standard output)")
+	commit := flag.String("fetch", "", "commit of github.com/mavlink/mavlink to download the definitions from first")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: mavgen [-defs dir] [-o file] [-fetch commit] <dialect.xml>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var messages []*message
+	var err error
+	if *commit != "" {
+		if !commitHash.MatchString(*commit) {
+			log.Fatalf("-fetch needs a full commit hash to pin the definitions to, got %q", *commit)
+		}
+		messages, err = fetch(fmt.Sprintf(upstream, *commit), *commit, *defs, flag.Arg(0))
+	} else {
+		messages, err = load(flag.Arg(0), func(name string) ([]byte, error) {
+			return os.ReadFile(filepath.Join(*defs, name))
+		})
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(flag.Arg(0), messages)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// fetch downloads the definition file name and the files it includes from
+// the mavlink repository at base, checked out at commit, into dir and loads
+// them. Definition files of dir that name no longer includes are removed.
+// The upstream licence is saved as dir/COPYING, and dir/README.md records
+// the commit.
+func fetch(base, commit, dir, name string) ([]*message, error) {
+	fetched := make(map[string]bool)
+	messages, err := load(name, func(name string) ([]byte, error) {
+		data, err := download(base + "message_definitions/v1.0/" + name)
+		if err != nil {
+			return nil, err
+		}
+		fetched[name] = true
+		return data, os.WriteFile(filepath.Join(dir, name), data, 0o644)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	licence, err := download(base + "COPYING")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "COPYING"), licence, 0o644); err != nil {
+		return nil, err
+	}
+	stale, err := filepath.Glob(filepath.Join(dir, "*.xml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range stale {
+		if !fetched[filepath.Base(path)] {
+			if err := os.Remove(path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := os.Remove(filepath.Join(dir, "LICENSE.gomavlib")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	readme := fmt.Sprintf(`The message definitions in this directory are %s and the files it
+includes, from message_definitions/v1.0 of github.com/mavlink/mavlink at
+commit %s. They are distributed under the upstream licence in COPYING.
+
+They were downloaded, and mavlink_dialect.go regenerated, by running from
+the module root:
+
+	go run ./internal/mavgen -defs internal/mavgen/message_definitions -fetch %s -o mavlink_dialect.go %s
+`, name, commit, commit, name)
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(readme), 0o644); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// download returns the body of the file at url.
+func download(url string) ([]byte, error) {
+	res, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, res.Status)
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,121 @@
+package dataflash
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// All returns an iterator over the messages from the current position to the
+// end of the log. Iteration ends cleanly at io.EOF and at a truncated last
+// message; any other error is yielded once with a nil message and ends the
+// iteration.
+func (p *Parser) All() iter.Seq2[*Message, error] {
+	return p.AllContext(context.Background())
+}
+
+// AllContext is like All but stops with ctx.Err() once ctx is done.
+func (p *Parser) AllContext(ctx context.Context) iter.Seq2[*Message, error] {
+	return func(yield func(*Message, error) bool) {
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			msg, err := p.ReadMessage()
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(msg, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Filtered sets the filter to the given names, which rewinds the log, and
+// returns an iterator over the matching messages. An invalid filter is
+// yielded as an error. The filter stays in place after iteration.
+func (p *Parser) Filtered(names ...string) iter.Seq2[*Message, error] {
+	return p.FilteredContext(context.Background(), names...)
+}
+
+// FilteredContext is like Filtered but stops with ctx.Err() once ctx is done.
+func (p *Parser) FilteredContext(ctx context.Context, names ...string) iter.Seq2[*Message, error] {
+	return func(yield func(*Message, error) bool) {
+		if err := p.SetFilter(names...); err != nil {
+			yield(nil, err)
+			return
+		}
+		for msg, err := range p.AllContext(ctx) {
+			if !yield(msg, err) {
+				return
+			}
+		}
+	}
+}
+
+// Between returns an iterator over the messages (subject to the filter) with
+// startUS <= TimeUS < endUS, with the same semantics as GetSlice with
+// SliceByTimeUS. It seeks using the index if one was built, otherwise it
+// rewinds and scans from the start.
+func (p *Parser) Between(startUS, endUS int64) iter.Seq2[*Message, error] {
+	return p.BetweenContext(context.Background(), startUS, endUS)
+}
+
+// BetweenContext is like Between but stops with ctx.Err() once ctx is done.
+func (p *Parser) BetweenContext(ctx context.Context, startUS, endUS int64) iter.Seq2[*Message, error] {
+	return p.slice(ctx, startUS, endUS, SliceByTimeUS)
+}
+
+// slice returns an iterator over the messages with start <= value < end,
+// where value is selected by sliceType. It stops at the first message whose
+// value is at least end.
+func (p *Parser) slice(ctx context.Context, start, end int64, sliceType SliceType) iter.Seq2[*Message, error] {
+	return func(yield func(*Message, error) bool) {
+		var err error
+		switch {
+		case sliceType != SliceByLineNo && sliceType != SliceByTimeUS:
+			err = fmt.Errorf("invalid slice type: %s", sliceType)
+		case p.index != nil && sliceType == SliceByLineNo:
+			err = p.seekLineNo(ctx, start)
+		case p.index != nil && sliceType == SliceByTimeUS:
+			err = p.seekTimeUS(ctx, start)
+		default:
+			err = p.Rewind()
+		}
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for msg, err := range p.AllContext(ctx) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			value := msg.LineNo
+			if sliceType == SliceByTimeUS {
+				value = msg.TimeUS
+			}
+
+			if value >= start && value < end {
+				if !yield(msg, nil) {
+					return
+				}
+			}
+
+			// Stop once we've passed the end
+			if value >= end {
+				return
+			}
+		}
+	}
+}
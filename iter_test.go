@@ -0,0 +1,139 @@
+package dataflash
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	parser, err := NewParserFromBytes(sampleLog(100))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	count := 0
+	for msg, err := range parser.All() {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+		if msg.LineNo != int64(count) {
+			t.Errorf("expected LineNo %d, got %d", count, msg.LineNo)
+		}
+	}
+	if count != 116 {
+		t.Errorf("expected 116 messages, got %d", count)
+	}
+
+	// A truncated last message ends iteration without an error
+	data := sampleLog(10)
+	parser, err = NewParserFromBytes(data[:len(data)-5])
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	count = 0
+	for _, err := range parser.All() {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		count++
+	}
+	if count != 16 {
+		t.Errorf("expected 16 messages, got %d", count)
+	}
+}
+
+func TestFiltered(t *testing.T) {
+	parser, err := NewParserFromBytes(sampleLog(100))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	// Read a few messages first; Filtered starts from the beginning
+	parser.ReadMessage()
+	parser.ReadMessage()
+
+	count := 0
+	for msg, err := range parser.Filtered("GPS") {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if msg.Name != "GPS" {
+			t.Errorf("expected GPS, got %s", msg.Name)
+		}
+		count++
+	}
+	if count != 10 {
+		t.Errorf("expected 10 GPS messages, got %d", count)
+	}
+
+	for msg, err := range parser.Filtered("INVALID") {
+		if err == nil {
+			t.Errorf("expected error for invalid filter, got %s", msg.Name)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	parser, err := NewParserFromBytes(sampleLog(100))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	var times []int64
+	for msg, err := range parser.Between(20000, 25000) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		times = append(times, msg.TimeUS)
+	}
+	// 20000 appears twice: IMU and GPS
+	want := []int64{20000, 20000, 21000, 22000, 23000, 24000}
+	if len(times) != len(want) {
+		t.Fatalf("got TimeUS %v, want %v", times, want)
+	}
+	for i := range want {
+		if times[i] != want[i] {
+			t.Errorf("got TimeUS %v, want %v", times, want)
+			break
+		}
+	}
+
+	// Stream parsers cannot seek back
+	stream := NewStreamParser(bytes.NewReader(sampleLog(10)))
+	for _, err := range stream.Between(0, 1000) {
+		if err != ErrNotSeekable {
+			t.Errorf("expected ErrNotSeekable, got %v", err)
+		}
+	}
+}
+
+func TestAllContextCancel(t *testing.T) {
+	parser, err := NewParserFromBytes(sampleLog(100))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	count := 0
+	var lastErr error
+	for _, err := range parser.AllContext(ctx) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		count++
+		if count == 10 {
+			cancel()
+		}
+	}
+	if !errors.Is(lastErr, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", lastErr)
+	}
+	if count != 10 {
+		t.Errorf("expected 10 messages before cancellation, got %d", count)
+	}
+}
@@ -0,0 +1,50 @@
+package dataflash
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLayoutMismatch is wrapped by the errors Layout.Check returns.
+var ErrLayoutMismatch = errors.New("message layout mismatch")
+
+// Layout identifies the layout of a message type that code was generated
+// for, such as by cmd/dfgen. Generated decoders read fields by position, so
+// they are only correct for logs that define the type the same way.
+type Layout struct {
+	Type    uint8  // Message type ID
+	Name    string // Message name
+	Format  string // Format string
+	Columns string // Comma-separated column names
+}
+
+// LayoutOf returns the layout of schema s.
+func LayoutOf(s *Schema) Layout {
+	return Layout{Type: s.Type, Name: s.Name, Format: s.Format, Columns: s.Columns}
+}
+
+// Check returns an error wrapping ErrLayoutMismatch if messages of schema s
+// are not laid out as l describes.
+func (l Layout) Check(s *Schema) error {
+	if s.Type == l.Type && s.Name == l.Name && s.Format == l.Format && s.Columns == l.Columns {
+		return nil
+	}
+	return fmt.Errorf("%w: log defines %s (type %d) as %q with columns %q, expected %s (type %d) as %q with columns %q",
+		ErrLayoutMismatch, s.Name, s.Type, s.Format, s.Columns, l.Name, l.Type, l.Format, l.Columns)
+}
+
+// CheckLayouts checks every definition the log gives each layout's type ID
+// against the layout, so that a log from other firmware is rejected before
+// it is read. Types the log does not define are ignored. Without a pre-scan
+// only the definitions read so far are checked.
+func CheckLayouts(p *Parser, layouts ...Layout) error {
+	var errs []error
+	for _, l := range layouts {
+		for _, s := range p.SchemaVersions(l.Type) {
+			if err := l.Check(s); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
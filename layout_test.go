@@ -0,0 +1,50 @@
+package dataflash
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckLayouts(t *testing.T) {
+	imu := LayoutOf(testIMUSchema)
+	gps := LayoutOf(testGPSSchema)
+	att := Layout{Type: 150, Name: "ATT", Format: "Qcc", Columns: "TimeUS,Roll,Pitch"}
+
+	parser, err := NewParserFromBytes(sampleLog(10))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if err := CheckLayouts(parser, imu, gps, att); err != nil {
+		t.Errorf("expected matching layouts, got %v", err)
+	}
+
+	// The second boot redefines IMU
+	parser, err = NewParserFromBytes(redefinedLog(10))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	err = CheckLayouts(parser, imu, gps)
+	if !errors.Is(err, ErrLayoutMismatch) {
+		t.Fatalf("expected ErrLayoutMismatch, got %v", err)
+	}
+	if err := CheckLayouts(parser, gps); err != nil {
+		t.Errorf("expected GPS to match, got %v", err)
+	}
+
+	if err := parser.SetFilter("IMU"); err != nil {
+		t.Fatalf("failed to set filter: %v", err)
+	}
+	var mismatched int
+	err = parser.Visit(func(h MessageHeader, r *FieldReader) error {
+		if err := imu.Check(r.Schema()); err != nil {
+			mismatched++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mismatched != 10 {
+		t.Errorf("expected the 10 redefined IMU messages to mismatch, got %d", mismatched)
+	}
+}
@@ -0,0 +1,205 @@
+package dataflash
+
+//go:generate go run ./internal/mavgen -defs internal/mavgen/message_definitions -o mavlink_dialect.go ardupilotmega.xml
+
+import (
+	"cmp"
+	"encoding/binary"
+	"math"
+	"slices"
+)
+
+// mavlinkField is a field of a MAVLink message as declared in the dialect
+// XML.
+type mavlinkField struct {
+	Name      string
+	Type      string // C type without the array length, such as uint16_t or char
+	ArrayLen  int    // Number of elements, 0 for scalars
+	Extension bool   // Declared after <extensions/>, absent from MAVLink 1 frames
+
+	offset int // Offset in the payload, set by compile
+}
+
+// mavlinkMessage is a MAVLink message definition.
+type mavlinkMessage struct {
+	ID     uint32
+	Name   string
+	Fields []mavlinkField // In declaration order
+
+	wire     []mavlinkField // In payload order, set by compile
+	crcExtra byte           // Seed mixed into the frame checksum
+	minLen   int            // Payload length without extensions
+	maxLen   int            // Payload length with extensions
+}
+
+// mavlinkTypeSizes gives the size in bytes of each MAVLink field type.
+var mavlinkTypeSizes = map[string]int{
+	"char":     1,
+	"uint8_t":  1,
+	"int8_t":   1,
+	"uint16_t": 2,
+	"int16_t":  2,
+	"uint32_t": 4,
+	"int32_t":  4,
+	"float":    4,
+	"uint64_t": 8,
+	"int64_t":  8,
+	"double":   8,
+}
+
+// compile orders the fields as they are sent and computes the payload
+// lengths and CRC_EXTRA. Fields other than extensions are sorted by type
+// size, largest first, keeping declaration order among equal sizes;
+// extensions follow in declaration order.
+func (m *mavlinkMessage) compile() {
+	var base, ext []mavlinkField
+	for _, f := range m.Fields {
+		if f.Extension {
+			ext = append(ext, f)
+		} else {
+			base = append(base, f)
+		}
+	}
+	slices.SortStableFunc(base, func(a, b mavlinkField) int {
+		return cmp.Compare(mavlinkTypeSizes[b.Type], mavlinkTypeSizes[a.Type])
+	})
+	m.wire = append(base, ext...)
+
+	crc := crcX25Init
+	crc = crcX25(crc, []byte(m.Name+" "))
+	offset := 0
+	for i := range m.wire {
+		f := &m.wire[i]
+		f.offset = offset
+		offset += f.size()
+		if f.Extension {
+			continue
+		}
+		m.minLen = offset
+		crc = crcX25(crc, []byte(f.Type+" "+f.Name+" "))
+		if f.ArrayLen > 0 {
+			crc = crcX25(crc, []byte{byte(f.ArrayLen)})
+		}
+	}
+	m.maxLen = offset
+	m.crcExtra = byte(crc) ^ byte(crc>>8)
+}
+
+// size returns the size of the field in bytes.
+func (f *mavlinkField) size() int {
+	return mavlinkTypeSizes[f.Type] * max(f.ArrayLen, 1)
+}
+
+// decode returns the fields of a payload padded to maxLen bytes. char
+// arrays become strings up to their first NUL, uint8_t arrays []byte and
+// other arrays slices of their type.
+func (m *mavlinkMessage) decode(payload []byte) map[string]any {
+	fields := make(map[string]any, len(m.wire))
+	for _, f := range m.wire {
+		b := payload[f.offset : f.offset+f.size()]
+		switch {
+		case f.Type == "char":
+			if i := slices.Index(b, 0); i >= 0 {
+				b = b[:i]
+			}
+			fields[f.Name] = string(b)
+		case f.ArrayLen == 0:
+			fields[f.Name] = mavlinkValue(f.Type, b)
+		case f.Type == "uint8_t":
+			fields[f.Name] = slices.Clone(b)
+		default:
+			fields[f.Name] = mavlinkArray(f.Type, b, f.ArrayLen)
+		}
+	}
+	return fields
+}
+
+// mavlinkValue decodes a little-endian scalar of the given type.
+func mavlinkValue(typ string, b []byte) any {
+	switch typ {
+	case "uint8_t":
+		return b[0]
+	case "int8_t":
+		return int8(b[0])
+	case "uint16_t":
+		return binary.LittleEndian.Uint16(b)
+	case "int16_t":
+		return int16(binary.LittleEndian.Uint16(b))
+	case "uint32_t":
+		return binary.LittleEndian.Uint32(b)
+	case "int32_t":
+		return int32(binary.LittleEndian.Uint32(b))
+	case "uint64_t":
+		return binary.LittleEndian.Uint64(b)
+	case "int64_t":
+		return int64(binary.LittleEndian.Uint64(b))
+	case "float":
+		return math.Float32frombits(binary.LittleEndian.Uint32(b))
+	case "double":
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	}
+	return nil
+}
+
+// mavlinkArray decodes n little-endian values of the given type into a
+// slice of that type.
+func mavlinkArray(typ string, b []byte, n int) any {
+	size := mavlinkTypeSizes[typ]
+	switch typ {
+	case "int8_t":
+		return mavlinkSlice[int8](typ, b, n, size)
+	case "uint16_t":
+		return mavlinkSlice[uint16](typ, b, n, size)
+	case "int16_t":
+		return mavlinkSlice[int16](typ, b, n, size)
+	case "uint32_t":
+		return mavlinkSlice[uint32](typ, b, n, size)
+	case "int32_t":
+		return mavlinkSlice[int32](typ, b, n, size)
+	case "uint64_t":
+		return mavlinkSlice[uint64](typ, b, n, size)
+	case "int64_t":
+		return mavlinkSlice[int64](typ, b, n, size)
+	case "float":
+		return mavlinkSlice[float32](typ, b, n, size)
+	case "double":
+		return mavlinkSlice[float64](typ, b, n, size)
+	}
+	return nil
+}
+
+// mavlinkSlice decodes n values of type T.
+func mavlinkSlice[T any](typ string, b []byte, n, size int) []T {
+	values := make([]T, n)
+	for i := range values {
+		values[i] = mavlinkValue(typ, b[i*size:]).(T)
+	}
+	return values
+}
+
+const crcX25Init uint16 = 0xffff
+
+// crcX25 accumulates data into the MAVLink checksum (CRC-16/MCRF4XX).
+func crcX25(crc uint16, data []byte) uint16 {
+	for _, b := range data {
+		tmp := b ^ byte(crc)
+		tmp ^= tmp << 4
+		crc = crc>>8 ^ uint16(tmp)<<8 ^ uint16(tmp)<<3 ^ uint16(tmp>>4)
+	}
+	return crc
+}
+
+// mavlinkMessages holds the dialect by message ID, compiled from
+// mavlinkDialect, which is generated from the ardupilotmega message
+// definitions vendored in internal/mavgen/message_definitions; their
+// README.md says where they come from. To update them, run go run
+// ./internal/mavgen -defs internal/mavgen/message_definitions -fetch <commit>
+// -o mavlink_dialect.go ardupilotmega.xml.
+var mavlinkMessages = func() map[uint32]*mavlinkMessage {
+	messages := make(map[uint32]*mavlinkMessage, len(mavlinkDialect))
+	for _, m := range mavlinkDialect {
+		m.compile()
+		messages[m.ID] = m
+	}
+	return messages
+}()
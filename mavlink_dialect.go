@@ -0,0 +1,3096 @@
+// Code generated by mavgen from ardupilotmega.xml. DO NOT EDIT.
+
+package dataflash
+
+// mavlinkDialect holds the messages of ardupilotmega.xml and the files it
+// includes, in message ID order. Frames of other messages are skipped.
+var mavlinkDialect = []*mavlinkMessage{
+	{ID: 0, Name: "HEARTBEAT", Fields: []mavlinkField{
+		{Name: "type", Type: "uint8_t"},
+		{Name: "autopilot", Type: "uint8_t"},
+		{Name: "base_mode", Type: "uint8_t"},
+		{Name: "custom_mode", Type: "uint32_t"},
+		{Name: "system_status", Type: "uint8_t"},
+		{Name: "mavlink_version", Type: "uint8_t"},
+	}},
+	{ID: 1, Name: "SYS_STATUS", Fields: []mavlinkField{
+		{Name: "onboard_control_sensors_present", Type: "uint32_t"},
+		{Name: "onboard_control_sensors_enabled", Type: "uint32_t"},
+		{Name: "onboard_control_sensors_health", Type: "uint32_t"},
+		{Name: "load", Type: "uint16_t"},
+		{Name: "voltage_battery", Type: "uint16_t"},
+		{Name: "current_battery", Type: "int16_t"},
+		{Name: "battery_remaining", Type: "int8_t"},
+		{Name: "drop_rate_comm", Type: "uint16_t"},
+		{Name: "errors_comm", Type: "uint16_t"},
+		{Name: "errors_count1", Type: "uint16_t"},
+		{Name: "errors_count2", Type: "uint16_t"},
+		{Name: "errors_count3", Type: "uint16_t"},
+		{Name: "errors_count4", Type: "uint16_t"},
+		{Name: "onboard_control_sensors_present_extended", Type: "uint32_t", Extension: true},
+		{Name: "onboard_control_sensors_enabled_extended", Type: "uint32_t", Extension: true},
+		{Name: "onboard_control_sensors_health_extended", Type: "uint32_t", Extension: true},
+	}},
+	{ID: 2, Name: "SYSTEM_TIME", Fields: []mavlinkField{
+		{Name: "time_unix_usec", Type: "uint64_t"},
+		{Name: "time_boot_ms", Type: "uint32_t"},
+	}},
+	{ID: 4, Name: "PING", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "seq", Type: "uint32_t"},
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+	}},
+	{ID: 5, Name: "CHANGE_OPERATOR_CONTROL", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "control_request", Type: "uint8_t"},
+		{Name: "version", Type: "uint8_t"},
+		{Name: "passkey", Type: "char", ArrayLen: 25},
+	}},
+	{ID: 6, Name: "CHANGE_OPERATOR_CONTROL_ACK", Fields: []mavlinkField{
+		{Name: "gcs_system_id", Type: "uint8_t"},
+		{Name: "control_request", Type: "uint8_t"},
+		{Name: "ack", Type: "uint8_t"},
+	}},
+	{ID: 7, Name: "AUTH_KEY", Fields: []mavlinkField{
+		{Name: "key", Type: "char", ArrayLen: 32},
+	}},
+	{ID: 8, Name: "LINK_NODE_STATUS", Fields: []mavlinkField{
+		{Name: "timestamp", Type: "uint64_t"},
+		{Name: "tx_buf", Type: "uint8_t"},
+		{Name: "rx_buf", Type: "uint8_t"},
+		{Name: "tx_rate", Type: "uint32_t"},
+		{Name: "rx_rate", Type: "uint32_t"},
+		{Name: "rx_parse_err", Type: "uint16_t"},
+		{Name: "tx_overflows", Type: "uint16_t"},
+		{Name: "rx_overflows", Type: "uint16_t"},
+		{Name: "messages_sent", Type: "uint32_t"},
+		{Name: "messages_received", Type: "uint32_t"},
+		{Name: "messages_lost", Type: "uint32_t"},
+	}},
+	{ID: 11, Name: "SET_MODE", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "base_mode", Type: "uint8_t"},
+		{Name: "custom_mode", Type: "uint32_t"},
+	}},
+	{ID: 20, Name: "PARAM_REQUEST_READ", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "param_id", Type: "char", ArrayLen: 16},
+		{Name: "param_index", Type: "int16_t"},
+	}},
+	{ID: 21, Name: "PARAM_REQUEST_LIST", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+	}},
+	{ID: 22, Name: "PARAM_VALUE", Fields: []mavlinkField{
+		{Name: "param_id", Type: "char", ArrayLen: 16},
+		{Name: "param_value", Type: "float"},
+		{Name: "param_type", Type: "uint8_t"},
+		{Name: "param_count", Type: "uint16_t"},
+		{Name: "param_index", Type: "uint16_t"},
+	}},
+	{ID: 23, Name: "PARAM_SET", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "param_id", Type: "char", ArrayLen: 16},
+		{Name: "param_value", Type: "float"},
+		{Name: "param_type", Type: "uint8_t"},
+	}},
+	{ID: 24, Name: "GPS_RAW_INT", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "fix_type", Type: "uint8_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "alt", Type: "int32_t"},
+		{Name: "eph", Type: "uint16_t"},
+		{Name: "epv", Type: "uint16_t"},
+		{Name: "vel", Type: "uint16_t"},
+		{Name: "cog", Type: "uint16_t"},
+		{Name: "satellites_visible", Type: "uint8_t"},
+		{Name: "alt_ellipsoid", Type: "int32_t", Extension: true},
+		{Name: "h_acc", Type: "uint32_t", Extension: true},
+		{Name: "v_acc", Type: "uint32_t", Extension: true},
+		{Name: "vel_acc", Type: "uint32_t", Extension: true},
+		{Name: "hdg_acc", Type: "uint32_t", Extension: true},
+		{Name: "yaw", Type: "uint16_t", Extension: true},
+	}},
+	{ID: 25, Name: "GPS_STATUS", Fields: []mavlinkField{
+		{Name: "satellites_visible", Type: "uint8_t"},
+		{Name: "satellite_prn", Type: "uint8_t", ArrayLen: 20},
+		{Name: "satellite_used", Type: "uint8_t", ArrayLen: 20},
+		{Name: "satellite_elevation", Type: "uint8_t", ArrayLen: 20},
+		{Name: "satellite_azimuth", Type: "uint8_t", ArrayLen: 20},
+		{Name: "satellite_snr", Type: "uint8_t", ArrayLen: 20},
+	}},
+	{ID: 26, Name: "SCALED_IMU", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "xacc", Type: "int16_t"},
+		{Name: "yacc", Type: "int16_t"},
+		{Name: "zacc", Type: "int16_t"},
+		{Name: "xgyro", Type: "int16_t"},
+		{Name: "ygyro", Type: "int16_t"},
+		{Name: "zgyro", Type: "int16_t"},
+		{Name: "xmag", Type: "int16_t"},
+		{Name: "ymag", Type: "int16_t"},
+		{Name: "zmag", Type: "int16_t"},
+		{Name: "temperature", Type: "int16_t", Extension: true},
+	}},
+	{ID: 27, Name: "RAW_IMU", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "xacc", Type: "int16_t"},
+		{Name: "yacc", Type: "int16_t"},
+		{Name: "zacc", Type: "int16_t"},
+		{Name: "xgyro", Type: "int16_t"},
+		{Name: "ygyro", Type: "int16_t"},
+		{Name: "zgyro", Type: "int16_t"},
+		{Name: "xmag", Type: "int16_t"},
+		{Name: "ymag", Type: "int16_t"},
+		{Name: "zmag", Type: "int16_t"},
+		{Name: "id", Type: "uint8_t", Extension: true},
+		{Name: "temperature", Type: "int16_t", Extension: true},
+	}},
+	{ID: 28, Name: "RAW_PRESSURE", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "press_abs", Type: "int16_t"},
+		{Name: "press_diff1", Type: "int16_t"},
+		{Name: "press_diff2", Type: "int16_t"},
+		{Name: "temperature", Type: "int16_t"},
+	}},
+	{ID: 29, Name: "SCALED_PRESSURE", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "press_abs", Type: "float"},
+		{Name: "press_diff", Type: "float"},
+		{Name: "temperature", Type: "int16_t"},
+		{Name: "temperature_press_diff", Type: "int16_t", Extension: true},
+	}},
+	{ID: 30, Name: "ATTITUDE", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "rollspeed", Type: "float"},
+		{Name: "pitchspeed", Type: "float"},
+		{Name: "yawspeed", Type: "float"},
+	}},
+	{ID: 31, Name: "ATTITUDE_QUATERNION", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "q1", Type: "float"},
+		{Name: "q2", Type: "float"},
+		{Name: "q3", Type: "float"},
+		{Name: "q4", Type: "float"},
+		{Name: "rollspeed", Type: "float"},
+		{Name: "pitchspeed", Type: "float"},
+		{Name: "yawspeed", Type: "float"},
+		{Name: "repr_offset_q", Type: "float", ArrayLen: 4, Extension: true},
+	}},
+	{ID: 32, Name: "LOCAL_POSITION_NED", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "vx", Type: "float"},
+		{Name: "vy", Type: "float"},
+		{Name: "vz", Type: "float"},
+	}},
+	{ID: 33, Name: "GLOBAL_POSITION_INT", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "alt", Type: "int32_t"},
+		{Name: "relative_alt", Type: "int32_t"},
+		{Name: "vx", Type: "int16_t"},
+		{Name: "vy", Type: "int16_t"},
+		{Name: "vz", Type: "int16_t"},
+		{Name: "hdg", Type: "uint16_t"},
+	}},
+	{ID: 34, Name: "RC_CHANNELS_SCALED", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "port", Type: "uint8_t"},
+		{Name: "chan1_scaled", Type: "int16_t"},
+		{Name: "chan2_scaled", Type: "int16_t"},
+		{Name: "chan3_scaled", Type: "int16_t"},
+		{Name: "chan4_scaled", Type: "int16_t"},
+		{Name: "chan5_scaled", Type: "int16_t"},
+		{Name: "chan6_scaled", Type: "int16_t"},
+		{Name: "chan7_scaled", Type: "int16_t"},
+		{Name: "chan8_scaled", Type: "int16_t"},
+		{Name: "rssi", Type: "uint8_t"},
+	}},
+	{ID: 35, Name: "RC_CHANNELS_RAW", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "port", Type: "uint8_t"},
+		{Name: "chan1_raw", Type: "uint16_t"},
+		{Name: "chan2_raw", Type: "uint16_t"},
+		{Name: "chan3_raw", Type: "uint16_t"},
+		{Name: "chan4_raw", Type: "uint16_t"},
+		{Name: "chan5_raw", Type: "uint16_t"},
+		{Name: "chan6_raw", Type: "uint16_t"},
+		{Name: "chan7_raw", Type: "uint16_t"},
+		{Name: "chan8_raw", Type: "uint16_t"},
+		{Name: "rssi", Type: "uint8_t"},
+	}},
+	{ID: 36, Name: "SERVO_OUTPUT_RAW", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint32_t"},
+		{Name: "port", Type: "uint8_t"},
+		{Name: "servo1_raw", Type: "uint16_t"},
+		{Name: "servo2_raw", Type: "uint16_t"},
+		{Name: "servo3_raw", Type: "uint16_t"},
+		{Name: "servo4_raw", Type: "uint16_t"},
+		{Name: "servo5_raw", Type: "uint16_t"},
+		{Name: "servo6_raw", Type: "uint16_t"},
+		{Name: "servo7_raw", Type: "uint16_t"},
+		{Name: "servo8_raw", Type: "uint16_t"},
+		{Name: "servo9_raw", Type: "uint16_t", Extension: true},
+		{Name: "servo10_raw", Type: "uint16_t", Extension: true},
+		{Name: "servo11_raw", Type: "uint16_t", Extension: true},
+		{Name: "servo12_raw", Type: "uint16_t", Extension: true},
+		{Name: "servo13_raw", Type: "uint16_t", Extension: true},
+		{Name: "servo14_raw", Type: "uint16_t", Extension: true},
+		{Name: "servo15_raw", Type: "uint16_t", Extension: true},
+		{Name: "servo16_raw", Type: "uint16_t", Extension: true},
+	}},
+	{ID: 37, Name: "MISSION_REQUEST_PARTIAL_LIST", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "start_index", Type: "int16_t"},
+		{Name: "end_index", Type: "int16_t"},
+		{Name: "mission_type", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 38, Name: "MISSION_WRITE_PARTIAL_LIST", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "start_index", Type: "int16_t"},
+		{Name: "end_index", Type: "int16_t"},
+		{Name: "mission_type", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 39, Name: "MISSION_ITEM", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "seq", Type: "uint16_t"},
+		{Name: "frame", Type: "uint8_t"},
+		{Name: "command", Type: "uint16_t"},
+		{Name: "current", Type: "uint8_t"},
+		{Name: "autocontinue", Type: "uint8_t"},
+		{Name: "param1", Type: "float"},
+		{Name: "param2", Type: "float"},
+		{Name: "param3", Type: "float"},
+		{Name: "param4", Type: "float"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "mission_type", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 40, Name: "MISSION_REQUEST", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "seq", Type: "uint16_t"},
+		{Name: "mission_type", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 41, Name: "MISSION_SET_CURRENT", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "seq", Type: "uint16_t"},
+	}},
+	{ID: 42, Name: "MISSION_CURRENT", Fields: []mavlinkField{
+		{Name: "seq", Type: "uint16_t"},
+		{Name: "total", Type: "uint16_t", Extension: true},
+		{Name: "mission_state", Type: "uint8_t", Extension: true},
+		{Name: "mission_mode", Type: "uint8_t", Extension: true},
+		{Name: "mission_id", Type: "uint32_t", Extension: true},
+		{Name: "fence_id", Type: "uint32_t", Extension: true},
+		{Name: "rally_points_id", Type: "uint32_t", Extension: true},
+	}},
+	{ID: 43, Name: "MISSION_REQUEST_LIST", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "mission_type", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 44, Name: "MISSION_COUNT", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "count", Type: "uint16_t"},
+		{Name: "mission_type", Type: "uint8_t", Extension: true},
+		{Name: "opaque_id", Type: "uint32_t", Extension: true},
+	}},
+	{ID: 45, Name: "MISSION_CLEAR_ALL", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "mission_type", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 46, Name: "MISSION_ITEM_REACHED", Fields: []mavlinkField{
+		{Name: "seq", Type: "uint16_t"},
+	}},
+	{ID: 47, Name: "MISSION_ACK", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "type", Type: "uint8_t"},
+		{Name: "mission_type", Type: "uint8_t", Extension: true},
+		{Name: "opaque_id", Type: "uint32_t", Extension: true},
+	}},
+	{ID: 48, Name: "SET_GPS_GLOBAL_ORIGIN", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "latitude", Type: "int32_t"},
+		{Name: "longitude", Type: "int32_t"},
+		{Name: "altitude", Type: "int32_t"},
+		{Name: "time_usec", Type: "uint64_t", Extension: true},
+	}},
+	{ID: 49, Name: "GPS_GLOBAL_ORIGIN", Fields: []mavlinkField{
+		{Name: "latitude", Type: "int32_t"},
+		{Name: "longitude", Type: "int32_t"},
+		{Name: "altitude", Type: "int32_t"},
+		{Name: "time_usec", Type: "uint64_t", Extension: true},
+	}},
+	{ID: 50, Name: "PARAM_MAP_RC", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "param_id", Type: "char", ArrayLen: 16},
+		{Name: "param_index", Type: "int16_t"},
+		{Name: "parameter_rc_channel_index", Type: "uint8_t"},
+		{Name: "param_value0", Type: "float"},
+		{Name: "scale", Type: "float"},
+		{Name: "param_value_min", Type: "float"},
+		{Name: "param_value_max", Type: "float"},
+	}},
+	{ID: 51, Name: "MISSION_REQUEST_INT", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "seq", Type: "uint16_t"},
+		{Name: "mission_type", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 54, Name: "SAFETY_SET_ALLOWED_AREA", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "frame", Type: "uint8_t"},
+		{Name: "p1x", Type: "float"},
+		{Name: "p1y", Type: "float"},
+		{Name: "p1z", Type: "float"},
+		{Name: "p2x", Type: "float"},
+		{Name: "p2y", Type: "float"},
+		{Name: "p2z", Type: "float"},
+	}},
+	{ID: 55, Name: "SAFETY_ALLOWED_AREA", Fields: []mavlinkField{
+		{Name: "frame", Type: "uint8_t"},
+		{Name: "p1x", Type: "float"},
+		{Name: "p1y", Type: "float"},
+		{Name: "p1z", Type: "float"},
+		{Name: "p2x", Type: "float"},
+		{Name: "p2y", Type: "float"},
+		{Name: "p2z", Type: "float"},
+	}},
+	{ID: 61, Name: "ATTITUDE_QUATERNION_COV", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "rollspeed", Type: "float"},
+		{Name: "pitchspeed", Type: "float"},
+		{Name: "yawspeed", Type: "float"},
+		{Name: "covariance", Type: "float", ArrayLen: 9},
+	}},
+	{ID: 62, Name: "NAV_CONTROLLER_OUTPUT", Fields: []mavlinkField{
+		{Name: "nav_roll", Type: "float"},
+		{Name: "nav_pitch", Type: "float"},
+		{Name: "nav_bearing", Type: "int16_t"},
+		{Name: "target_bearing", Type: "int16_t"},
+		{Name: "wp_dist", Type: "uint16_t"},
+		{Name: "alt_error", Type: "float"},
+		{Name: "aspd_error", Type: "float"},
+		{Name: "xtrack_error", Type: "float"},
+	}},
+	{ID: 63, Name: "GLOBAL_POSITION_INT_COV", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "estimator_type", Type: "uint8_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "alt", Type: "int32_t"},
+		{Name: "relative_alt", Type: "int32_t"},
+		{Name: "vx", Type: "float"},
+		{Name: "vy", Type: "float"},
+		{Name: "vz", Type: "float"},
+		{Name: "covariance", Type: "float", ArrayLen: 36},
+	}},
+	{ID: 64, Name: "LOCAL_POSITION_NED_COV", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "estimator_type", Type: "uint8_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "vx", Type: "float"},
+		{Name: "vy", Type: "float"},
+		{Name: "vz", Type: "float"},
+		{Name: "ax", Type: "float"},
+		{Name: "ay", Type: "float"},
+		{Name: "az", Type: "float"},
+		{Name: "covariance", Type: "float", ArrayLen: 45},
+	}},
+	{ID: 65, Name: "RC_CHANNELS", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "chancount", Type: "uint8_t"},
+		{Name: "chan1_raw", Type: "uint16_t"},
+		{Name: "chan2_raw", Type: "uint16_t"},
+		{Name: "chan3_raw", Type: "uint16_t"},
+		{Name: "chan4_raw", Type: "uint16_t"},
+		{Name: "chan5_raw", Type: "uint16_t"},
+		{Name: "chan6_raw", Type: "uint16_t"},
+		{Name: "chan7_raw", Type: "uint16_t"},
+		{Name: "chan8_raw", Type: "uint16_t"},
+		{Name: "chan9_raw", Type: "uint16_t"},
+		{Name: "chan10_raw", Type: "uint16_t"},
+		{Name: "chan11_raw", Type: "uint16_t"},
+		{Name: "chan12_raw", Type: "uint16_t"},
+		{Name: "chan13_raw", Type: "uint16_t"},
+		{Name: "chan14_raw", Type: "uint16_t"},
+		{Name: "chan15_raw", Type: "uint16_t"},
+		{Name: "chan16_raw", Type: "uint16_t"},
+		{Name: "chan17_raw", Type: "uint16_t"},
+		{Name: "chan18_raw", Type: "uint16_t"},
+		{Name: "rssi", Type: "uint8_t"},
+	}},
+	{ID: 66, Name: "REQUEST_DATA_STREAM", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "req_stream_id", Type: "uint8_t"},
+		{Name: "req_message_rate", Type: "uint16_t"},
+		{Name: "start_stop", Type: "uint8_t"},
+	}},
+	{ID: 67, Name: "DATA_STREAM", Fields: []mavlinkField{
+		{Name: "stream_id", Type: "uint8_t"},
+		{Name: "message_rate", Type: "uint16_t"},
+		{Name: "on_off", Type: "uint8_t"},
+	}},
+	{ID: 69, Name: "MANUAL_CONTROL", Fields: []mavlinkField{
+		{Name: "target", Type: "uint8_t"},
+		{Name: "x", Type: "int16_t"},
+		{Name: "y", Type: "int16_t"},
+		{Name: "z", Type: "int16_t"},
+		{Name: "r", Type: "int16_t"},
+		{Name: "buttons", Type: "uint16_t"},
+		{Name: "buttons2", Type: "uint16_t", Extension: true},
+		{Name: "enabled_extensions", Type: "uint8_t", Extension: true},
+		{Name: "s", Type: "int16_t", Extension: true},
+		{Name: "t", Type: "int16_t", Extension: true},
+		{Name: "aux1", Type: "int16_t", Extension: true},
+		{Name: "aux2", Type: "int16_t", Extension: true},
+		{Name: "aux3", Type: "int16_t", Extension: true},
+		{Name: "aux4", Type: "int16_t", Extension: true},
+		{Name: "aux5", Type: "int16_t", Extension: true},
+		{Name: "aux6", Type: "int16_t", Extension: true},
+	}},
+	{ID: 70, Name: "RC_CHANNELS_OVERRIDE", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "chan1_raw", Type: "uint16_t"},
+		{Name: "chan2_raw", Type: "uint16_t"},
+		{Name: "chan3_raw", Type: "uint16_t"},
+		{Name: "chan4_raw", Type: "uint16_t"},
+		{Name: "chan5_raw", Type: "uint16_t"},
+		{Name: "chan6_raw", Type: "uint16_t"},
+		{Name: "chan7_raw", Type: "uint16_t"},
+		{Name: "chan8_raw", Type: "uint16_t"},
+		{Name: "chan9_raw", Type: "uint16_t", Extension: true},
+		{Name: "chan10_raw", Type: "uint16_t", Extension: true},
+		{Name: "chan11_raw", Type: "uint16_t", Extension: true},
+		{Name: "chan12_raw", Type: "uint16_t", Extension: true},
+		{Name: "chan13_raw", Type: "uint16_t", Extension: true},
+		{Name: "chan14_raw", Type: "uint16_t", Extension: true},
+		{Name: "chan15_raw", Type: "uint16_t", Extension: true},
+		{Name: "chan16_raw", Type: "uint16_t", Extension: true},
+		{Name: "chan17_raw", Type: "uint16_t", Extension: true},
+		{Name: "chan18_raw", Type: "uint16_t", Extension: true},
+	}},
+	{ID: 73, Name: "MISSION_ITEM_INT", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "seq", Type: "uint16_t"},
+		{Name: "frame", Type: "uint8_t"},
+		{Name: "command", Type: "uint16_t"},
+		{Name: "current", Type: "uint8_t"},
+		{Name: "autocontinue", Type: "uint8_t"},
+		{Name: "param1", Type: "float"},
+		{Name: "param2", Type: "float"},
+		{Name: "param3", Type: "float"},
+		{Name: "param4", Type: "float"},
+		{Name: "x", Type: "int32_t"},
+		{Name: "y", Type: "int32_t"},
+		{Name: "z", Type: "float"},
+		{Name: "mission_type", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 74, Name: "VFR_HUD", Fields: []mavlinkField{
+		{Name: "airspeed", Type: "float"},
+		{Name: "groundspeed", Type: "float"},
+		{Name: "heading", Type: "int16_t"},
+		{Name: "throttle", Type: "uint16_t"},
+		{Name: "alt", Type: "float"},
+		{Name: "climb", Type: "float"},
+	}},
+	{ID: 75, Name: "COMMAND_INT", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "frame", Type: "uint8_t"},
+		{Name: "command", Type: "uint16_t"},
+		{Name: "current", Type: "uint8_t"},
+		{Name: "autocontinue", Type: "uint8_t"},
+		{Name: "param1", Type: "float"},
+		{Name: "param2", Type: "float"},
+		{Name: "param3", Type: "float"},
+		{Name: "param4", Type: "float"},
+		{Name: "x", Type: "int32_t"},
+		{Name: "y", Type: "int32_t"},
+		{Name: "z", Type: "float"},
+	}},
+	{ID: 76, Name: "COMMAND_LONG", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "command", Type: "uint16_t"},
+		{Name: "confirmation", Type: "uint8_t"},
+		{Name: "param1", Type: "float"},
+		{Name: "param2", Type: "float"},
+		{Name: "param3", Type: "float"},
+		{Name: "param4", Type: "float"},
+		{Name: "param5", Type: "float"},
+		{Name: "param6", Type: "float"},
+		{Name: "param7", Type: "float"},
+	}},
+	{ID: 77, Name: "COMMAND_ACK", Fields: []mavlinkField{
+		{Name: "command", Type: "uint16_t"},
+		{Name: "result", Type: "uint8_t"},
+		{Name: "progress", Type: "uint8_t", Extension: true},
+		{Name: "result_param2", Type: "int32_t", Extension: true},
+		{Name: "target_system", Type: "uint8_t", Extension: true},
+		{Name: "target_component", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 80, Name: "COMMAND_CANCEL", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "command", Type: "uint16_t"},
+	}},
+	{ID: 81, Name: "MANUAL_SETPOINT", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "thrust", Type: "float"},
+		{Name: "mode_switch", Type: "uint8_t"},
+		{Name: "manual_override_switch", Type: "uint8_t"},
+	}},
+	{ID: 82, Name: "SET_ATTITUDE_TARGET", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "type_mask", Type: "uint8_t"},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "body_roll_rate", Type: "float"},
+		{Name: "body_pitch_rate", Type: "float"},
+		{Name: "body_yaw_rate", Type: "float"},
+		{Name: "thrust", Type: "float"},
+		{Name: "thrust_body", Type: "float", ArrayLen: 3, Extension: true},
+	}},
+	{ID: 83, Name: "ATTITUDE_TARGET", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "type_mask", Type: "uint8_t"},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "body_roll_rate", Type: "float"},
+		{Name: "body_pitch_rate", Type: "float"},
+		{Name: "body_yaw_rate", Type: "float"},
+		{Name: "thrust", Type: "float"},
+	}},
+	{ID: 84, Name: "SET_POSITION_TARGET_LOCAL_NED", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "coordinate_frame", Type: "uint8_t"},
+		{Name: "type_mask", Type: "uint16_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "vx", Type: "float"},
+		{Name: "vy", Type: "float"},
+		{Name: "vz", Type: "float"},
+		{Name: "afx", Type: "float"},
+		{Name: "afy", Type: "float"},
+		{Name: "afz", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "yaw_rate", Type: "float"},
+	}},
+	{ID: 85, Name: "POSITION_TARGET_LOCAL_NED", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "coordinate_frame", Type: "uint8_t"},
+		{Name: "type_mask", Type: "uint16_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "vx", Type: "float"},
+		{Name: "vy", Type: "float"},
+		{Name: "vz", Type: "float"},
+		{Name: "afx", Type: "float"},
+		{Name: "afy", Type: "float"},
+		{Name: "afz", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "yaw_rate", Type: "float"},
+	}},
+	{ID: 86, Name: "SET_POSITION_TARGET_GLOBAL_INT", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "coordinate_frame", Type: "uint8_t"},
+		{Name: "type_mask", Type: "uint16_t"},
+		{Name: "lat_int", Type: "int32_t"},
+		{Name: "lon_int", Type: "int32_t"},
+		{Name: "alt", Type: "float"},
+		{Name: "vx", Type: "float"},
+		{Name: "vy", Type: "float"},
+		{Name: "vz", Type: "float"},
+		{Name: "afx", Type: "float"},
+		{Name: "afy", Type: "float"},
+		{Name: "afz", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "yaw_rate", Type: "float"},
+	}},
+	{ID: 87, Name: "POSITION_TARGET_GLOBAL_INT", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "coordinate_frame", Type: "uint8_t"},
+		{Name: "type_mask", Type: "uint16_t"},
+		{Name: "lat_int", Type: "int32_t"},
+		{Name: "lon_int", Type: "int32_t"},
+		{Name: "alt", Type: "float"},
+		{Name: "vx", Type: "float"},
+		{Name: "vy", Type: "float"},
+		{Name: "vz", Type: "float"},
+		{Name: "afx", Type: "float"},
+		{Name: "afy", Type: "float"},
+		{Name: "afz", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "yaw_rate", Type: "float"},
+	}},
+	{ID: 89, Name: "LOCAL_POSITION_NED_SYSTEM_GLOBAL_OFFSET", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+	}},
+	{ID: 90, Name: "HIL_STATE", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "rollspeed", Type: "float"},
+		{Name: "pitchspeed", Type: "float"},
+		{Name: "yawspeed", Type: "float"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "alt", Type: "int32_t"},
+		{Name: "vx", Type: "int16_t"},
+		{Name: "vy", Type: "int16_t"},
+		{Name: "vz", Type: "int16_t"},
+		{Name: "xacc", Type: "int16_t"},
+		{Name: "yacc", Type: "int16_t"},
+		{Name: "zacc", Type: "int16_t"},
+	}},
+	{ID: 91, Name: "HIL_CONTROLS", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "roll_ailerons", Type: "float"},
+		{Name: "pitch_elevator", Type: "float"},
+		{Name: "yaw_rudder", Type: "float"},
+		{Name: "throttle", Type: "float"},
+		{Name: "aux1", Type: "float"},
+		{Name: "aux2", Type: "float"},
+		{Name: "aux3", Type: "float"},
+		{Name: "aux4", Type: "float"},
+		{Name: "mode", Type: "uint8_t"},
+		{Name: "nav_mode", Type: "uint8_t"},
+	}},
+	{ID: 92, Name: "HIL_RC_INPUTS_RAW", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "chan1_raw", Type: "uint16_t"},
+		{Name: "chan2_raw", Type: "uint16_t"},
+		{Name: "chan3_raw", Type: "uint16_t"},
+		{Name: "chan4_raw", Type: "uint16_t"},
+		{Name: "chan5_raw", Type: "uint16_t"},
+		{Name: "chan6_raw", Type: "uint16_t"},
+		{Name: "chan7_raw", Type: "uint16_t"},
+		{Name: "chan8_raw", Type: "uint16_t"},
+		{Name: "chan9_raw", Type: "uint16_t"},
+		{Name: "chan10_raw", Type: "uint16_t"},
+		{Name: "chan11_raw", Type: "uint16_t"},
+		{Name: "chan12_raw", Type: "uint16_t"},
+		{Name: "rssi", Type: "uint8_t"},
+	}},
+	{ID: 93, Name: "HIL_ACTUATOR_CONTROLS", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "controls", Type: "float", ArrayLen: 16},
+		{Name: "mode", Type: "uint8_t"},
+		{Name: "flags", Type: "uint64_t"},
+	}},
+	{ID: 100, Name: "OPTICAL_FLOW", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "sensor_id", Type: "uint8_t"},
+		{Name: "flow_x", Type: "int16_t"},
+		{Name: "flow_y", Type: "int16_t"},
+		{Name: "flow_comp_m_x", Type: "float"},
+		{Name: "flow_comp_m_y", Type: "float"},
+		{Name: "quality", Type: "uint8_t"},
+		{Name: "ground_distance", Type: "float"},
+		{Name: "flow_rate_x", Type: "float", Extension: true},
+		{Name: "flow_rate_y", Type: "float", Extension: true},
+	}},
+	{ID: 101, Name: "GLOBAL_VISION_POSITION_ESTIMATE", Fields: []mavlinkField{
+		{Name: "usec", Type: "uint64_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "covariance", Type: "float", ArrayLen: 21, Extension: true},
+		{Name: "reset_counter", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 102, Name: "VISION_POSITION_ESTIMATE", Fields: []mavlinkField{
+		{Name: "usec", Type: "uint64_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "covariance", Type: "float", ArrayLen: 21, Extension: true},
+		{Name: "reset_counter", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 103, Name: "VISION_SPEED_ESTIMATE", Fields: []mavlinkField{
+		{Name: "usec", Type: "uint64_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "covariance", Type: "float", ArrayLen: 9, Extension: true},
+		{Name: "reset_counter", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 104, Name: "VICON_POSITION_ESTIMATE", Fields: []mavlinkField{
+		{Name: "usec", Type: "uint64_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "covariance", Type: "float", ArrayLen: 21, Extension: true},
+	}},
+	{ID: 105, Name: "HIGHRES_IMU", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "xacc", Type: "float"},
+		{Name: "yacc", Type: "float"},
+		{Name: "zacc", Type: "float"},
+		{Name: "xgyro", Type: "float"},
+		{Name: "ygyro", Type: "float"},
+		{Name: "zgyro", Type: "float"},
+		{Name: "xmag", Type: "float"},
+		{Name: "ymag", Type: "float"},
+		{Name: "zmag", Type: "float"},
+		{Name: "abs_pressure", Type: "float"},
+		{Name: "diff_pressure", Type: "float"},
+		{Name: "pressure_alt", Type: "float"},
+		{Name: "temperature", Type: "float"},
+		{Name: "fields_updated", Type: "uint16_t"},
+		{Name: "id", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 106, Name: "OPTICAL_FLOW_RAD", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "sensor_id", Type: "uint8_t"},
+		{Name: "integration_time_us", Type: "uint32_t"},
+		{Name: "integrated_x", Type: "float"},
+		{Name: "integrated_y", Type: "float"},
+		{Name: "integrated_xgyro", Type: "float"},
+		{Name: "integrated_ygyro", Type: "float"},
+		{Name: "integrated_zgyro", Type: "float"},
+		{Name: "temperature", Type: "int16_t"},
+		{Name: "quality", Type: "uint8_t"},
+		{Name: "time_delta_distance_us", Type: "uint32_t"},
+		{Name: "distance", Type: "float"},
+	}},
+	{ID: 107, Name: "HIL_SENSOR", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "xacc", Type: "float"},
+		{Name: "yacc", Type: "float"},
+		{Name: "zacc", Type: "float"},
+		{Name: "xgyro", Type: "float"},
+		{Name: "ygyro", Type: "float"},
+		{Name: "zgyro", Type: "float"},
+		{Name: "xmag", Type: "float"},
+		{Name: "ymag", Type: "float"},
+		{Name: "zmag", Type: "float"},
+		{Name: "abs_pressure", Type: "float"},
+		{Name: "diff_pressure", Type: "float"},
+		{Name: "pressure_alt", Type: "float"},
+		{Name: "temperature", Type: "float"},
+		{Name: "fields_updated", Type: "uint32_t"},
+		{Name: "id", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 108, Name: "SIM_STATE", Fields: []mavlinkField{
+		{Name: "q1", Type: "float"},
+		{Name: "q2", Type: "float"},
+		{Name: "q3", Type: "float"},
+		{Name: "q4", Type: "float"},
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "xacc", Type: "float"},
+		{Name: "yacc", Type: "float"},
+		{Name: "zacc", Type: "float"},
+		{Name: "xgyro", Type: "float"},
+		{Name: "ygyro", Type: "float"},
+		{Name: "zgyro", Type: "float"},
+		{Name: "lat", Type: "float"},
+		{Name: "lon", Type: "float"},
+		{Name: "alt", Type: "float"},
+		{Name: "std_dev_horz", Type: "float"},
+		{Name: "std_dev_vert", Type: "float"},
+		{Name: "vn", Type: "float"},
+		{Name: "ve", Type: "float"},
+		{Name: "vd", Type: "float"},
+		{Name: "lat_int", Type: "int32_t", Extension: true},
+		{Name: "lon_int", Type: "int32_t", Extension: true},
+	}},
+	{ID: 109, Name: "RADIO_STATUS", Fields: []mavlinkField{
+		{Name: "rssi", Type: "uint8_t"},
+		{Name: "remrssi", Type: "uint8_t"},
+		{Name: "txbuf", Type: "uint8_t"},
+		{Name: "noise", Type: "uint8_t"},
+		{Name: "remnoise", Type: "uint8_t"},
+		{Name: "rxerrors", Type: "uint16_t"},
+		{Name: "fixed", Type: "uint16_t"},
+	}},
+	{ID: 110, Name: "FILE_TRANSFER_PROTOCOL", Fields: []mavlinkField{
+		{Name: "target_network", Type: "uint8_t"},
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "payload", Type: "uint8_t", ArrayLen: 251},
+	}},
+	{ID: 111, Name: "TIMESYNC", Fields: []mavlinkField{
+		{Name: "tc1", Type: "int64_t"},
+		{Name: "ts1", Type: "int64_t"},
+		{Name: "target_system", Type: "uint8_t", Extension: true},
+		{Name: "target_component", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 112, Name: "CAMERA_TRIGGER", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "seq", Type: "uint32_t"},
+	}},
+	{ID: 113, Name: "HIL_GPS", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "fix_type", Type: "uint8_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "alt", Type: "int32_t"},
+		{Name: "eph", Type: "uint16_t"},
+		{Name: "epv", Type: "uint16_t"},
+		{Name: "vel", Type: "uint16_t"},
+		{Name: "vn", Type: "int16_t"},
+		{Name: "ve", Type: "int16_t"},
+		{Name: "vd", Type: "int16_t"},
+		{Name: "cog", Type: "uint16_t"},
+		{Name: "satellites_visible", Type: "uint8_t"},
+		{Name: "id", Type: "uint8_t", Extension: true},
+		{Name: "yaw", Type: "uint16_t", Extension: true},
+	}},
+	{ID: 114, Name: "HIL_OPTICAL_FLOW", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "sensor_id", Type: "uint8_t"},
+		{Name: "integration_time_us", Type: "uint32_t"},
+		{Name: "integrated_x", Type: "float"},
+		{Name: "integrated_y", Type: "float"},
+		{Name: "integrated_xgyro", Type: "float"},
+		{Name: "integrated_ygyro", Type: "float"},
+		{Name: "integrated_zgyro", Type: "float"},
+		{Name: "temperature", Type: "int16_t"},
+		{Name: "quality", Type: "uint8_t"},
+		{Name: "time_delta_distance_us", Type: "uint32_t"},
+		{Name: "distance", Type: "float"},
+	}},
+	{ID: 115, Name: "HIL_STATE_QUATERNION", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "attitude_quaternion", Type: "float", ArrayLen: 4},
+		{Name: "rollspeed", Type: "float"},
+		{Name: "pitchspeed", Type: "float"},
+		{Name: "yawspeed", Type: "float"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "alt", Type: "int32_t"},
+		{Name: "vx", Type: "int16_t"},
+		{Name: "vy", Type: "int16_t"},
+		{Name: "vz", Type: "int16_t"},
+		{Name: "ind_airspeed", Type: "uint16_t"},
+		{Name: "true_airspeed", Type: "uint16_t"},
+		{Name: "xacc", Type: "int16_t"},
+		{Name: "yacc", Type: "int16_t"},
+		{Name: "zacc", Type: "int16_t"},
+	}},
+	{ID: 116, Name: "SCALED_IMU2", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "xacc", Type: "int16_t"},
+		{Name: "yacc", Type: "int16_t"},
+		{Name: "zacc", Type: "int16_t"},
+		{Name: "xgyro", Type: "int16_t"},
+		{Name: "ygyro", Type: "int16_t"},
+		{Name: "zgyro", Type: "int16_t"},
+		{Name: "xmag", Type: "int16_t"},
+		{Name: "ymag", Type: "int16_t"},
+		{Name: "zmag", Type: "int16_t"},
+		{Name: "temperature", Type: "int16_t", Extension: true},
+	}},
+	{ID: 117, Name: "LOG_REQUEST_LIST", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "start", Type: "uint16_t"},
+		{Name: "end", Type: "uint16_t"},
+	}},
+	{ID: 118, Name: "LOG_ENTRY", Fields: []mavlinkField{
+		{Name: "id", Type: "uint16_t"},
+		{Name: "num_logs", Type: "uint16_t"},
+		{Name: "last_log_num", Type: "uint16_t"},
+		{Name: "time_utc", Type: "uint32_t"},
+		{Name: "size", Type: "uint32_t"},
+	}},
+	{ID: 119, Name: "LOG_REQUEST_DATA", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "id", Type: "uint16_t"},
+		{Name: "ofs", Type: "uint32_t"},
+		{Name: "count", Type: "uint32_t"},
+	}},
+	{ID: 120, Name: "LOG_DATA", Fields: []mavlinkField{
+		{Name: "id", Type: "uint16_t"},
+		{Name: "ofs", Type: "uint32_t"},
+		{Name: "count", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 90},
+	}},
+	{ID: 121, Name: "LOG_ERASE", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+	}},
+	{ID: 122, Name: "LOG_REQUEST_END", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+	}},
+	{ID: 123, Name: "GPS_INJECT_DATA", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "len", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 110},
+	}},
+	{ID: 124, Name: "GPS2_RAW", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "fix_type", Type: "uint8_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "alt", Type: "int32_t"},
+		{Name: "eph", Type: "uint16_t"},
+		{Name: "epv", Type: "uint16_t"},
+		{Name: "vel", Type: "uint16_t"},
+		{Name: "cog", Type: "uint16_t"},
+		{Name: "satellites_visible", Type: "uint8_t"},
+		{Name: "dgps_numch", Type: "uint8_t"},
+		{Name: "dgps_age", Type: "uint32_t"},
+		{Name: "yaw", Type: "uint16_t", Extension: true},
+		{Name: "alt_ellipsoid", Type: "int32_t", Extension: true},
+		{Name: "h_acc", Type: "uint32_t", Extension: true},
+		{Name: "v_acc", Type: "uint32_t", Extension: true},
+		{Name: "vel_acc", Type: "uint32_t", Extension: true},
+		{Name: "hdg_acc", Type: "uint32_t", Extension: true},
+	}},
+	{ID: 125, Name: "POWER_STATUS", Fields: []mavlinkField{
+		{Name: "Vcc", Type: "uint16_t"},
+		{Name: "Vservo", Type: "uint16_t"},
+		{Name: "flags", Type: "uint16_t"},
+	}},
+	{ID: 126, Name: "SERIAL_CONTROL", Fields: []mavlinkField{
+		{Name: "device", Type: "uint8_t"},
+		{Name: "flags", Type: "uint8_t"},
+		{Name: "timeout", Type: "uint16_t"},
+		{Name: "baudrate", Type: "uint32_t"},
+		{Name: "count", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 70},
+		{Name: "target_system", Type: "uint8_t", Extension: true},
+		{Name: "target_component", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 127, Name: "GPS_RTK", Fields: []mavlinkField{
+		{Name: "time_last_baseline_ms", Type: "uint32_t"},
+		{Name: "rtk_receiver_id", Type: "uint8_t"},
+		{Name: "wn", Type: "uint16_t"},
+		{Name: "tow", Type: "uint32_t"},
+		{Name: "rtk_health", Type: "uint8_t"},
+		{Name: "rtk_rate", Type: "uint8_t"},
+		{Name: "nsats", Type: "uint8_t"},
+		{Name: "baseline_coords_type", Type: "uint8_t"},
+		{Name: "baseline_a_mm", Type: "int32_t"},
+		{Name: "baseline_b_mm", Type: "int32_t"},
+		{Name: "baseline_c_mm", Type: "int32_t"},
+		{Name: "accuracy", Type: "uint32_t"},
+		{Name: "iar_num_hypotheses", Type: "int32_t"},
+	}},
+	{ID: 128, Name: "GPS2_RTK", Fields: []mavlinkField{
+		{Name: "time_last_baseline_ms", Type: "uint32_t"},
+		{Name: "rtk_receiver_id", Type: "uint8_t"},
+		{Name: "wn", Type: "uint16_t"},
+		{Name: "tow", Type: "uint32_t"},
+		{Name: "rtk_health", Type: "uint8_t"},
+		{Name: "rtk_rate", Type: "uint8_t"},
+		{Name: "nsats", Type: "uint8_t"},
+		{Name: "baseline_coords_type", Type: "uint8_t"},
+		{Name: "baseline_a_mm", Type: "int32_t"},
+		{Name: "baseline_b_mm", Type: "int32_t"},
+		{Name: "baseline_c_mm", Type: "int32_t"},
+		{Name: "accuracy", Type: "uint32_t"},
+		{Name: "iar_num_hypotheses", Type: "int32_t"},
+	}},
+	{ID: 129, Name: "SCALED_IMU3", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "xacc", Type: "int16_t"},
+		{Name: "yacc", Type: "int16_t"},
+		{Name: "zacc", Type: "int16_t"},
+		{Name: "xgyro", Type: "int16_t"},
+		{Name: "ygyro", Type: "int16_t"},
+		{Name: "zgyro", Type: "int16_t"},
+		{Name: "xmag", Type: "int16_t"},
+		{Name: "ymag", Type: "int16_t"},
+		{Name: "zmag", Type: "int16_t"},
+		{Name: "temperature", Type: "int16_t", Extension: true},
+	}},
+	{ID: 130, Name: "DATA_TRANSMISSION_HANDSHAKE", Fields: []mavlinkField{
+		{Name: "type", Type: "uint8_t"},
+		{Name: "size", Type: "uint32_t"},
+		{Name: "width", Type: "uint16_t"},
+		{Name: "height", Type: "uint16_t"},
+		{Name: "packets", Type: "uint16_t"},
+		{Name: "payload", Type: "uint8_t"},
+		{Name: "jpg_quality", Type: "uint8_t"},
+	}},
+	{ID: 131, Name: "ENCAPSULATED_DATA", Fields: []mavlinkField{
+		{Name: "seqnr", Type: "uint16_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 253},
+	}},
+	{ID: 132, Name: "DISTANCE_SENSOR", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "min_distance", Type: "uint16_t"},
+		{Name: "max_distance", Type: "uint16_t"},
+		{Name: "current_distance", Type: "uint16_t"},
+		{Name: "type", Type: "uint8_t"},
+		{Name: "id", Type: "uint8_t"},
+		{Name: "orientation", Type: "uint8_t"},
+		{Name: "covariance", Type: "uint8_t"},
+		{Name: "horizontal_fov", Type: "float", Extension: true},
+		{Name: "vertical_fov", Type: "float", Extension: true},
+		{Name: "quaternion", Type: "float", ArrayLen: 4, Extension: true},
+		{Name: "signal_quality", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 133, Name: "TERRAIN_REQUEST", Fields: []mavlinkField{
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "grid_spacing", Type: "uint16_t"},
+		{Name: "mask", Type: "uint64_t"},
+	}},
+	{ID: 134, Name: "TERRAIN_DATA", Fields: []mavlinkField{
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "grid_spacing", Type: "uint16_t"},
+		{Name: "gridbit", Type: "uint8_t"},
+		{Name: "data", Type: "int16_t", ArrayLen: 16},
+	}},
+	{ID: 135, Name: "TERRAIN_CHECK", Fields: []mavlinkField{
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+	}},
+	{ID: 136, Name: "TERRAIN_REPORT", Fields: []mavlinkField{
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "spacing", Type: "uint16_t"},
+		{Name: "terrain_height", Type: "float"},
+		{Name: "current_height", Type: "float"},
+		{Name: "pending", Type: "uint16_t"},
+		{Name: "loaded", Type: "uint16_t"},
+	}},
+	{ID: 137, Name: "SCALED_PRESSURE2", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "press_abs", Type: "float"},
+		{Name: "press_diff", Type: "float"},
+		{Name: "temperature", Type: "int16_t"},
+		{Name: "temperature_press_diff", Type: "int16_t", Extension: true},
+	}},
+	{ID: 138, Name: "ATT_POS_MOCAP", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "covariance", Type: "float", ArrayLen: 21, Extension: true},
+	}},
+	{ID: 139, Name: "SET_ACTUATOR_CONTROL_TARGET", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "group_mlx", Type: "uint8_t"},
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "controls", Type: "float", ArrayLen: 8},
+	}},
+	{ID: 140, Name: "ACTUATOR_CONTROL_TARGET", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "group_mlx", Type: "uint8_t"},
+		{Name: "controls", Type: "float", ArrayLen: 8},
+	}},
+	{ID: 141, Name: "ALTITUDE", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "altitude_monotonic", Type: "float"},
+		{Name: "altitude_amsl", Type: "float"},
+		{Name: "altitude_local", Type: "float"},
+		{Name: "altitude_relative", Type: "float"},
+		{Name: "altitude_terrain", Type: "float"},
+		{Name: "bottom_clearance", Type: "float"},
+	}},
+	{ID: 142, Name: "RESOURCE_REQUEST", Fields: []mavlinkField{
+		{Name: "request_id", Type: "uint8_t"},
+		{Name: "uri_type", Type: "uint8_t"},
+		{Name: "uri", Type: "uint8_t", ArrayLen: 120},
+		{Name: "transfer_type", Type: "uint8_t"},
+		{Name: "storage", Type: "uint8_t", ArrayLen: 120},
+	}},
+	{ID: 143, Name: "SCALED_PRESSURE3", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "press_abs", Type: "float"},
+		{Name: "press_diff", Type: "float"},
+		{Name: "temperature", Type: "int16_t"},
+		{Name: "temperature_press_diff", Type: "int16_t", Extension: true},
+	}},
+	{ID: 144, Name: "FOLLOW_TARGET", Fields: []mavlinkField{
+		{Name: "timestamp", Type: "uint64_t"},
+		{Name: "est_capabilities", Type: "uint8_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "alt", Type: "float"},
+		{Name: "vel", Type: "float", ArrayLen: 3},
+		{Name: "acc", Type: "float", ArrayLen: 3},
+		{Name: "attitude_q", Type: "float", ArrayLen: 4},
+		{Name: "rates", Type: "float", ArrayLen: 3},
+		{Name: "position_cov", Type: "float", ArrayLen: 3},
+		{Name: "custom_state", Type: "uint64_t"},
+	}},
+	{ID: 146, Name: "CONTROL_SYSTEM_STATE", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "x_acc", Type: "float"},
+		{Name: "y_acc", Type: "float"},
+		{Name: "z_acc", Type: "float"},
+		{Name: "x_vel", Type: "float"},
+		{Name: "y_vel", Type: "float"},
+		{Name: "z_vel", Type: "float"},
+		{Name: "x_pos", Type: "float"},
+		{Name: "y_pos", Type: "float"},
+		{Name: "z_pos", Type: "float"},
+		{Name: "airspeed", Type: "float"},
+		{Name: "vel_variance", Type: "float", ArrayLen: 3},
+		{Name: "pos_variance", Type: "float", ArrayLen: 3},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "roll_rate", Type: "float"},
+		{Name: "pitch_rate", Type: "float"},
+		{Name: "yaw_rate", Type: "float"},
+	}},
+	{ID: 147, Name: "BATTERY_STATUS", Fields: []mavlinkField{
+		{Name: "id", Type: "uint8_t"},
+		{Name: "battery_function", Type: "uint8_t"},
+		{Name: "type", Type: "uint8_t"},
+		{Name: "temperature", Type: "int16_t"},
+		{Name: "voltages", Type: "uint16_t", ArrayLen: 10},
+		{Name: "current_battery", Type: "int16_t"},
+		{Name: "current_consumed", Type: "int32_t"},
+		{Name: "energy_consumed", Type: "int32_t"},
+		{Name: "battery_remaining", Type: "int8_t"},
+		{Name: "time_remaining", Type: "int32_t", Extension: true},
+		{Name: "charge_state", Type: "uint8_t", Extension: true},
+		{Name: "voltages_ext", Type: "uint16_t", ArrayLen: 4, Extension: true},
+		{Name: "mode", Type: "uint8_t", Extension: true},
+		{Name: "fault_bitmask", Type: "uint32_t", Extension: true},
+	}},
+	{ID: 148, Name: "AUTOPILOT_VERSION", Fields: []mavlinkField{
+		{Name: "capabilities", Type: "uint64_t"},
+		{Name: "flight_sw_version", Type: "uint32_t"},
+		{Name: "middleware_sw_version", Type: "uint32_t"},
+		{Name: "os_sw_version", Type: "uint32_t"},
+		{Name: "board_version", Type: "uint32_t"},
+		{Name: "flight_custom_version", Type: "uint8_t", ArrayLen: 8},
+		{Name: "middleware_custom_version", Type: "uint8_t", ArrayLen: 8},
+		{Name: "os_custom_version", Type: "uint8_t", ArrayLen: 8},
+		{Name: "vendor_id", Type: "uint16_t"},
+		{Name: "product_id", Type: "uint16_t"},
+		{Name: "uid", Type: "uint64_t"},
+		{Name: "uid2", Type: "uint8_t", ArrayLen: 18, Extension: true},
+	}},
+	{ID: 149, Name: "LANDING_TARGET", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "target_num", Type: "uint8_t"},
+		{Name: "frame", Type: "uint8_t"},
+		{Name: "angle_x", Type: "float"},
+		{Name: "angle_y", Type: "float"},
+		{Name: "distance", Type: "float"},
+		{Name: "size_x", Type: "float"},
+		{Name: "size_y", Type: "float"},
+		{Name: "x", Type: "float", Extension: true},
+		{Name: "y", Type: "float", Extension: true},
+		{Name: "z", Type: "float", Extension: true},
+		{Name: "q", Type: "float", ArrayLen: 4, Extension: true},
+		{Name: "type", Type: "uint8_t", Extension: true},
+		{Name: "position_valid", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 150, Name: "SENSOR_OFFSETS", Fields: []mavlinkField{
+		{Name: "mag_ofs_x", Type: "int16_t"},
+		{Name: "mag_ofs_y", Type: "int16_t"},
+		{Name: "mag_ofs_z", Type: "int16_t"},
+		{Name: "mag_declination", Type: "float"},
+		{Name: "raw_press", Type: "int32_t"},
+		{Name: "raw_temp", Type: "int32_t"},
+		{Name: "gyro_cal_x", Type: "float"},
+		{Name: "gyro_cal_y", Type: "float"},
+		{Name: "gyro_cal_z", Type: "float"},
+		{Name: "accel_cal_x", Type: "float"},
+		{Name: "accel_cal_y", Type: "float"},
+		{Name: "accel_cal_z", Type: "float"},
+	}},
+	{ID: 151, Name: "SET_MAG_OFFSETS", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "mag_ofs_x", Type: "int16_t"},
+		{Name: "mag_ofs_y", Type: "int16_t"},
+		{Name: "mag_ofs_z", Type: "int16_t"},
+	}},
+	{ID: 152, Name: "MEMINFO", Fields: []mavlinkField{
+		{Name: "brkval", Type: "uint16_t"},
+		{Name: "freemem", Type: "uint16_t"},
+		{Name: "freemem32", Type: "uint32_t", Extension: true},
+	}},
+	{ID: 153, Name: "AP_ADC", Fields: []mavlinkField{
+		{Name: "adc1", Type: "uint16_t"},
+		{Name: "adc2", Type: "uint16_t"},
+		{Name: "adc3", Type: "uint16_t"},
+		{Name: "adc4", Type: "uint16_t"},
+		{Name: "adc5", Type: "uint16_t"},
+		{Name: "adc6", Type: "uint16_t"},
+	}},
+	{ID: 154, Name: "DIGICAM_CONFIGURE", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "mode", Type: "uint8_t"},
+		{Name: "shutter_speed", Type: "uint16_t"},
+		{Name: "aperture", Type: "uint8_t"},
+		{Name: "iso", Type: "uint8_t"},
+		{Name: "exposure_type", Type: "uint8_t"},
+		{Name: "command_id", Type: "uint8_t"},
+		{Name: "engine_cut_off", Type: "uint8_t"},
+		{Name: "extra_param", Type: "uint8_t"},
+		{Name: "extra_value", Type: "float"},
+	}},
+	{ID: 155, Name: "DIGICAM_CONTROL", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "session", Type: "uint8_t"},
+		{Name: "zoom_pos", Type: "uint8_t"},
+		{Name: "zoom_step", Type: "int8_t"},
+		{Name: "focus_lock", Type: "uint8_t"},
+		{Name: "shot", Type: "uint8_t"},
+		{Name: "command_id", Type: "uint8_t"},
+		{Name: "extra_param", Type: "uint8_t"},
+		{Name: "extra_value", Type: "float"},
+	}},
+	{ID: 156, Name: "MOUNT_CONFIGURE", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "mount_mode", Type: "uint8_t"},
+		{Name: "stab_roll", Type: "uint8_t"},
+		{Name: "stab_pitch", Type: "uint8_t"},
+		{Name: "stab_yaw", Type: "uint8_t"},
+	}},
+	{ID: 157, Name: "MOUNT_CONTROL", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "input_a", Type: "int32_t"},
+		{Name: "input_b", Type: "int32_t"},
+		{Name: "input_c", Type: "int32_t"},
+		{Name: "save_position", Type: "uint8_t"},
+	}},
+	{ID: 158, Name: "MOUNT_STATUS", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "pointing_a", Type: "int32_t"},
+		{Name: "pointing_b", Type: "int32_t"},
+		{Name: "pointing_c", Type: "int32_t"},
+		{Name: "mount_mode", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 160, Name: "FENCE_POINT", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "idx", Type: "uint8_t"},
+		{Name: "count", Type: "uint8_t"},
+		{Name: "lat", Type: "float"},
+		{Name: "lng", Type: "float"},
+	}},
+	{ID: 161, Name: "FENCE_FETCH_POINT", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "idx", Type: "uint8_t"},
+	}},
+	{ID: 162, Name: "FENCE_STATUS", Fields: []mavlinkField{
+		{Name: "breach_status", Type: "uint8_t"},
+		{Name: "breach_count", Type: "uint16_t"},
+		{Name: "breach_type", Type: "uint8_t"},
+		{Name: "breach_time", Type: "uint32_t"},
+		{Name: "breach_mitigation", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 163, Name: "AHRS", Fields: []mavlinkField{
+		{Name: "omegaIx", Type: "float"},
+		{Name: "omegaIy", Type: "float"},
+		{Name: "omegaIz", Type: "float"},
+		{Name: "accel_weight", Type: "float"},
+		{Name: "renorm_val", Type: "float"},
+		{Name: "error_rp", Type: "float"},
+		{Name: "error_yaw", Type: "float"},
+	}},
+	{ID: 164, Name: "SIMSTATE", Fields: []mavlinkField{
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "xacc", Type: "float"},
+		{Name: "yacc", Type: "float"},
+		{Name: "zacc", Type: "float"},
+		{Name: "xgyro", Type: "float"},
+		{Name: "ygyro", Type: "float"},
+		{Name: "zgyro", Type: "float"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lng", Type: "int32_t"},
+	}},
+	{ID: 165, Name: "HWSTATUS", Fields: []mavlinkField{
+		{Name: "Vcc", Type: "uint16_t"},
+		{Name: "I2Cerr", Type: "uint8_t"},
+	}},
+	{ID: 166, Name: "RADIO", Fields: []mavlinkField{
+		{Name: "rssi", Type: "uint8_t"},
+		{Name: "remrssi", Type: "uint8_t"},
+		{Name: "txbuf", Type: "uint8_t"},
+		{Name: "noise", Type: "uint8_t"},
+		{Name: "remnoise", Type: "uint8_t"},
+		{Name: "rxerrors", Type: "uint16_t"},
+		{Name: "fixed", Type: "uint16_t"},
+	}},
+	{ID: 167, Name: "LIMITS_STATUS", Fields: []mavlinkField{
+		{Name: "limits_state", Type: "uint8_t"},
+		{Name: "last_trigger", Type: "uint32_t"},
+		{Name: "last_action", Type: "uint32_t"},
+		{Name: "last_recovery", Type: "uint32_t"},
+		{Name: "last_clear", Type: "uint32_t"},
+		{Name: "breach_count", Type: "uint16_t"},
+		{Name: "mods_enabled", Type: "uint8_t"},
+		{Name: "mods_required", Type: "uint8_t"},
+		{Name: "mods_triggered", Type: "uint8_t"},
+	}},
+	{ID: 168, Name: "WIND", Fields: []mavlinkField{
+		{Name: "direction", Type: "float"},
+		{Name: "speed", Type: "float"},
+		{Name: "speed_z", Type: "float"},
+	}},
+	{ID: 169, Name: "DATA16", Fields: []mavlinkField{
+		{Name: "type", Type: "uint8_t"},
+		{Name: "len", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 16},
+	}},
+	{ID: 170, Name: "DATA32", Fields: []mavlinkField{
+		{Name: "type", Type: "uint8_t"},
+		{Name: "len", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 32},
+	}},
+	{ID: 171, Name: "DATA64", Fields: []mavlinkField{
+		{Name: "type", Type: "uint8_t"},
+		{Name: "len", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 64},
+	}},
+	{ID: 172, Name: "DATA96", Fields: []mavlinkField{
+		{Name: "type", Type: "uint8_t"},
+		{Name: "len", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 96},
+	}},
+	{ID: 173, Name: "RANGEFINDER", Fields: []mavlinkField{
+		{Name: "distance", Type: "float"},
+		{Name: "voltage", Type: "float"},
+	}},
+	{ID: 174, Name: "AIRSPEED_AUTOCAL", Fields: []mavlinkField{
+		{Name: "vx", Type: "float"},
+		{Name: "vy", Type: "float"},
+		{Name: "vz", Type: "float"},
+		{Name: "diff_pressure", Type: "float"},
+		{Name: "EAS2TAS", Type: "float"},
+		{Name: "ratio", Type: "float"},
+		{Name: "state_x", Type: "float"},
+		{Name: "state_y", Type: "float"},
+		{Name: "state_z", Type: "float"},
+		{Name: "Pax", Type: "float"},
+		{Name: "Pby", Type: "float"},
+		{Name: "Pcz", Type: "float"},
+	}},
+	{ID: 175, Name: "RALLY_POINT", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "idx", Type: "uint8_t"},
+		{Name: "count", Type: "uint8_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lng", Type: "int32_t"},
+		{Name: "alt", Type: "int16_t"},
+		{Name: "break_alt", Type: "int16_t"},
+		{Name: "land_dir", Type: "uint16_t"},
+		{Name: "flags", Type: "uint8_t"},
+	}},
+	{ID: 176, Name: "RALLY_FETCH_POINT", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "idx", Type: "uint8_t"},
+	}},
+	{ID: 177, Name: "COMPASSMOT_STATUS", Fields: []mavlinkField{
+		{Name: "throttle", Type: "uint16_t"},
+		{Name: "current", Type: "float"},
+		{Name: "interference", Type: "uint16_t"},
+		{Name: "CompensationX", Type: "float"},
+		{Name: "CompensationY", Type: "float"},
+		{Name: "CompensationZ", Type: "float"},
+	}},
+	{ID: 178, Name: "AHRS2", Fields: []mavlinkField{
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "altitude", Type: "float"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lng", Type: "int32_t"},
+	}},
+	{ID: 179, Name: "CAMERA_STATUS", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "cam_idx", Type: "uint8_t"},
+		{Name: "img_idx", Type: "uint16_t"},
+		{Name: "event_id", Type: "uint8_t"},
+		{Name: "p1", Type: "float"},
+		{Name: "p2", Type: "float"},
+		{Name: "p3", Type: "float"},
+		{Name: "p4", Type: "float"},
+	}},
+	{ID: 180, Name: "CAMERA_FEEDBACK", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "cam_idx", Type: "uint8_t"},
+		{Name: "img_idx", Type: "uint16_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lng", Type: "int32_t"},
+		{Name: "alt_msl", Type: "float"},
+		{Name: "alt_rel", Type: "float"},
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "foc_len", Type: "float"},
+		{Name: "flags", Type: "uint8_t"},
+		{Name: "completed_captures", Type: "uint16_t", Extension: true},
+	}},
+	{ID: 181, Name: "BATTERY2", Fields: []mavlinkField{
+		{Name: "voltage", Type: "uint16_t"},
+		{Name: "current_battery", Type: "int16_t"},
+	}},
+	{ID: 182, Name: "AHRS3", Fields: []mavlinkField{
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "altitude", Type: "float"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lng", Type: "int32_t"},
+		{Name: "v1", Type: "float"},
+		{Name: "v2", Type: "float"},
+		{Name: "v3", Type: "float"},
+		{Name: "v4", Type: "float"},
+	}},
+	{ID: 183, Name: "AUTOPILOT_VERSION_REQUEST", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+	}},
+	{ID: 184, Name: "REMOTE_LOG_DATA_BLOCK", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "seqno", Type: "uint32_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 200},
+	}},
+	{ID: 185, Name: "REMOTE_LOG_BLOCK_STATUS", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "seqno", Type: "uint32_t"},
+		{Name: "status", Type: "uint8_t"},
+	}},
+	{ID: 186, Name: "LED_CONTROL", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "instance", Type: "uint8_t"},
+		{Name: "pattern", Type: "uint8_t"},
+		{Name: "custom_len", Type: "uint8_t"},
+		{Name: "custom_bytes", Type: "uint8_t", ArrayLen: 24},
+	}},
+	{ID: 191, Name: "MAG_CAL_PROGRESS", Fields: []mavlinkField{
+		{Name: "compass_id", Type: "uint8_t"},
+		{Name: "cal_mask", Type: "uint8_t"},
+		{Name: "cal_status", Type: "uint8_t"},
+		{Name: "attempt", Type: "uint8_t"},
+		{Name: "completion_pct", Type: "uint8_t"},
+		{Name: "completion_mask", Type: "uint8_t", ArrayLen: 10},
+		{Name: "direction_x", Type: "float"},
+		{Name: "direction_y", Type: "float"},
+		{Name: "direction_z", Type: "float"},
+	}},
+	{ID: 192, Name: "MAG_CAL_REPORT", Fields: []mavlinkField{
+		{Name: "compass_id", Type: "uint8_t"},
+		{Name: "cal_mask", Type: "uint8_t"},
+		{Name: "cal_status", Type: "uint8_t"},
+		{Name: "autosaved", Type: "uint8_t"},
+		{Name: "fitness", Type: "float"},
+		{Name: "ofs_x", Type: "float"},
+		{Name: "ofs_y", Type: "float"},
+		{Name: "ofs_z", Type: "float"},
+		{Name: "diag_x", Type: "float"},
+		{Name: "diag_y", Type: "float"},
+		{Name: "diag_z", Type: "float"},
+		{Name: "offdiag_x", Type: "float"},
+		{Name: "offdiag_y", Type: "float"},
+		{Name: "offdiag_z", Type: "float"},
+		{Name: "orientation_confidence", Type: "float", Extension: true},
+		{Name: "old_orientation", Type: "uint8_t", Extension: true},
+		{Name: "new_orientation", Type: "uint8_t", Extension: true},
+		{Name: "scale_factor", Type: "float", Extension: true},
+	}},
+	{ID: 193, Name: "EKF_STATUS_REPORT", Fields: []mavlinkField{
+		{Name: "flags", Type: "uint16_t"},
+		{Name: "velocity_variance", Type: "float"},
+		{Name: "pos_horiz_variance", Type: "float"},
+		{Name: "pos_vert_variance", Type: "float"},
+		{Name: "compass_variance", Type: "float"},
+		{Name: "terrain_alt_variance", Type: "float"},
+		{Name: "airspeed_variance", Type: "float", Extension: true},
+	}},
+	{ID: 194, Name: "PID_TUNING", Fields: []mavlinkField{
+		{Name: "axis", Type: "uint8_t"},
+		{Name: "desired", Type: "float"},
+		{Name: "achieved", Type: "float"},
+		{Name: "FF", Type: "float"},
+		{Name: "P", Type: "float"},
+		{Name: "I", Type: "float"},
+		{Name: "D", Type: "float"},
+		{Name: "SRate", Type: "float", Extension: true},
+		{Name: "PDmod", Type: "float", Extension: true},
+	}},
+	{ID: 195, Name: "DEEPSTALL", Fields: []mavlinkField{
+		{Name: "landing_lat", Type: "int32_t"},
+		{Name: "landing_lon", Type: "int32_t"},
+		{Name: "path_lat", Type: "int32_t"},
+		{Name: "path_lon", Type: "int32_t"},
+		{Name: "arc_entry_lat", Type: "int32_t"},
+		{Name: "arc_entry_lon", Type: "int32_t"},
+		{Name: "altitude", Type: "float"},
+		{Name: "expected_travel_distance", Type: "float"},
+		{Name: "cross_track_error", Type: "float"},
+		{Name: "stage", Type: "uint8_t"},
+	}},
+	{ID: 200, Name: "GIMBAL_REPORT", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "delta_time", Type: "float"},
+		{Name: "delta_angle_x", Type: "float"},
+		{Name: "delta_angle_y", Type: "float"},
+		{Name: "delta_angle_z", Type: "float"},
+		{Name: "delta_velocity_x", Type: "float"},
+		{Name: "delta_velocity_y", Type: "float"},
+		{Name: "delta_velocity_z", Type: "float"},
+		{Name: "joint_roll", Type: "float"},
+		{Name: "joint_el", Type: "float"},
+		{Name: "joint_az", Type: "float"},
+	}},
+	{ID: 201, Name: "GIMBAL_CONTROL", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "demanded_rate_x", Type: "float"},
+		{Name: "demanded_rate_y", Type: "float"},
+		{Name: "demanded_rate_z", Type: "float"},
+	}},
+	{ID: 214, Name: "GIMBAL_TORQUE_CMD_REPORT", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "rl_torque_cmd", Type: "int16_t"},
+		{Name: "el_torque_cmd", Type: "int16_t"},
+		{Name: "az_torque_cmd", Type: "int16_t"},
+	}},
+	{ID: 215, Name: "GOPRO_HEARTBEAT", Fields: []mavlinkField{
+		{Name: "status", Type: "uint8_t"},
+		{Name: "capture_mode", Type: "uint8_t"},
+		{Name: "flags", Type: "uint8_t"},
+	}},
+	{ID: 216, Name: "GOPRO_GET_REQUEST", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "cmd_id", Type: "uint8_t"},
+	}},
+	{ID: 217, Name: "GOPRO_GET_RESPONSE", Fields: []mavlinkField{
+		{Name: "cmd_id", Type: "uint8_t"},
+		{Name: "status", Type: "uint8_t"},
+		{Name: "value", Type: "uint8_t", ArrayLen: 4},
+	}},
+	{ID: 218, Name: "GOPRO_SET_REQUEST", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "cmd_id", Type: "uint8_t"},
+		{Name: "value", Type: "uint8_t", ArrayLen: 4},
+	}},
+	{ID: 219, Name: "GOPRO_SET_RESPONSE", Fields: []mavlinkField{
+		{Name: "cmd_id", Type: "uint8_t"},
+		{Name: "status", Type: "uint8_t"},
+	}},
+	{ID: 225, Name: "EFI_STATUS", Fields: []mavlinkField{
+		{Name: "health", Type: "uint8_t"},
+		{Name: "ecu_index", Type: "float"},
+		{Name: "rpm", Type: "float"},
+		{Name: "fuel_consumed", Type: "float"},
+		{Name: "fuel_flow", Type: "float"},
+		{Name: "engine_load", Type: "float"},
+		{Name: "throttle_position", Type: "float"},
+		{Name: "spark_dwell_time", Type: "float"},
+		{Name: "barometric_pressure", Type: "float"},
+		{Name: "intake_manifold_pressure", Type: "float"},
+		{Name: "intake_manifold_temperature", Type: "float"},
+		{Name: "cylinder_head_temperature", Type: "float"},
+		{Name: "ignition_timing", Type: "float"},
+		{Name: "injection_time", Type: "float"},
+		{Name: "exhaust_gas_temperature", Type: "float"},
+		{Name: "throttle_out", Type: "float"},
+		{Name: "pt_compensation", Type: "float"},
+		{Name: "ignition_voltage", Type: "float", Extension: true},
+		{Name: "fuel_pressure", Type: "float", Extension: true},
+	}},
+	{ID: 226, Name: "RPM", Fields: []mavlinkField{
+		{Name: "rpm1", Type: "float"},
+		{Name: "rpm2", Type: "float"},
+	}},
+	{ID: 230, Name: "ESTIMATOR_STATUS", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "flags", Type: "uint16_t"},
+		{Name: "vel_ratio", Type: "float"},
+		{Name: "pos_horiz_ratio", Type: "float"},
+		{Name: "pos_vert_ratio", Type: "float"},
+		{Name: "mag_ratio", Type: "float"},
+		{Name: "hagl_ratio", Type: "float"},
+		{Name: "tas_ratio", Type: "float"},
+		{Name: "pos_horiz_accuracy", Type: "float"},
+		{Name: "pos_vert_accuracy", Type: "float"},
+	}},
+	{ID: 231, Name: "WIND_COV", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "wind_x", Type: "float"},
+		{Name: "wind_y", Type: "float"},
+		{Name: "wind_z", Type: "float"},
+		{Name: "var_horiz", Type: "float"},
+		{Name: "var_vert", Type: "float"},
+		{Name: "wind_alt", Type: "float"},
+		{Name: "horiz_accuracy", Type: "float"},
+		{Name: "vert_accuracy", Type: "float"},
+	}},
+	{ID: 232, Name: "GPS_INPUT", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "gps_id", Type: "uint8_t"},
+		{Name: "ignore_flags", Type: "uint16_t"},
+		{Name: "time_week_ms", Type: "uint32_t"},
+		{Name: "time_week", Type: "uint16_t"},
+		{Name: "fix_type", Type: "uint8_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "alt", Type: "float"},
+		{Name: "hdop", Type: "float"},
+		{Name: "vdop", Type: "float"},
+		{Name: "vn", Type: "float"},
+		{Name: "ve", Type: "float"},
+		{Name: "vd", Type: "float"},
+		{Name: "speed_accuracy", Type: "float"},
+		{Name: "horiz_accuracy", Type: "float"},
+		{Name: "vert_accuracy", Type: "float"},
+		{Name: "satellites_visible", Type: "uint8_t"},
+		{Name: "yaw", Type: "uint16_t", Extension: true},
+	}},
+	{ID: 233, Name: "GPS_RTCM_DATA", Fields: []mavlinkField{
+		{Name: "flags", Type: "uint8_t"},
+		{Name: "len", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 180},
+	}},
+	{ID: 234, Name: "HIGH_LATENCY", Fields: []mavlinkField{
+		{Name: "base_mode", Type: "uint8_t"},
+		{Name: "custom_mode", Type: "uint32_t"},
+		{Name: "landed_state", Type: "uint8_t"},
+		{Name: "roll", Type: "int16_t"},
+		{Name: "pitch", Type: "int16_t"},
+		{Name: "heading", Type: "uint16_t"},
+		{Name: "throttle", Type: "int8_t"},
+		{Name: "heading_sp", Type: "int16_t"},
+		{Name: "latitude", Type: "int32_t"},
+		{Name: "longitude", Type: "int32_t"},
+		{Name: "altitude_amsl", Type: "int16_t"},
+		{Name: "altitude_sp", Type: "int16_t"},
+		{Name: "airspeed", Type: "uint8_t"},
+		{Name: "airspeed_sp", Type: "uint8_t"},
+		{Name: "groundspeed", Type: "uint8_t"},
+		{Name: "climb_rate", Type: "int8_t"},
+		{Name: "gps_nsat", Type: "uint8_t"},
+		{Name: "gps_fix_type", Type: "uint8_t"},
+		{Name: "battery_remaining", Type: "uint8_t"},
+		{Name: "temperature", Type: "int8_t"},
+		{Name: "temperature_air", Type: "int8_t"},
+		{Name: "failsafe", Type: "uint8_t"},
+		{Name: "wp_num", Type: "uint8_t"},
+		{Name: "wp_distance", Type: "uint16_t"},
+	}},
+	{ID: 235, Name: "HIGH_LATENCY2", Fields: []mavlinkField{
+		{Name: "timestamp", Type: "uint32_t"},
+		{Name: "type", Type: "uint8_t"},
+		{Name: "autopilot", Type: "uint8_t"},
+		{Name: "custom_mode", Type: "uint16_t"},
+		{Name: "latitude", Type: "int32_t"},
+		{Name: "longitude", Type: "int32_t"},
+		{Name: "altitude", Type: "int16_t"},
+		{Name: "target_altitude", Type: "int16_t"},
+		{Name: "heading", Type: "uint8_t"},
+		{Name: "target_heading", Type: "uint8_t"},
+		{Name: "target_distance", Type: "uint16_t"},
+		{Name: "throttle", Type: "uint8_t"},
+		{Name: "airspeed", Type: "uint8_t"},
+		{Name: "airspeed_sp", Type: "uint8_t"},
+		{Name: "groundspeed", Type: "uint8_t"},
+		{Name: "windspeed", Type: "uint8_t"},
+		{Name: "wind_heading", Type: "uint8_t"},
+		{Name: "eph", Type: "uint8_t"},
+		{Name: "epv", Type: "uint8_t"},
+		{Name: "temperature_air", Type: "int8_t"},
+		{Name: "climb_rate", Type: "int8_t"},
+		{Name: "battery", Type: "int8_t"},
+		{Name: "wp_num", Type: "uint16_t"},
+		{Name: "failure_flags", Type: "uint16_t"},
+		{Name: "custom0", Type: "int8_t"},
+		{Name: "custom1", Type: "int8_t"},
+		{Name: "custom2", Type: "int8_t"},
+	}},
+	{ID: 241, Name: "VIBRATION", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "vibration_x", Type: "float"},
+		{Name: "vibration_y", Type: "float"},
+		{Name: "vibration_z", Type: "float"},
+		{Name: "clipping_0", Type: "uint32_t"},
+		{Name: "clipping_1", Type: "uint32_t"},
+		{Name: "clipping_2", Type: "uint32_t"},
+	}},
+	{ID: 242, Name: "HOME_POSITION", Fields: []mavlinkField{
+		{Name: "latitude", Type: "int32_t"},
+		{Name: "longitude", Type: "int32_t"},
+		{Name: "altitude", Type: "int32_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "approach_x", Type: "float"},
+		{Name: "approach_y", Type: "float"},
+		{Name: "approach_z", Type: "float"},
+		{Name: "time_usec", Type: "uint64_t", Extension: true},
+	}},
+	{ID: 243, Name: "SET_HOME_POSITION", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "latitude", Type: "int32_t"},
+		{Name: "longitude", Type: "int32_t"},
+		{Name: "altitude", Type: "int32_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "approach_x", Type: "float"},
+		{Name: "approach_y", Type: "float"},
+		{Name: "approach_z", Type: "float"},
+		{Name: "time_usec", Type: "uint64_t", Extension: true},
+	}},
+	{ID: 244, Name: "MESSAGE_INTERVAL", Fields: []mavlinkField{
+		{Name: "message_id", Type: "uint16_t"},
+		{Name: "interval_us", Type: "int32_t"},
+	}},
+	{ID: 245, Name: "EXTENDED_SYS_STATE", Fields: []mavlinkField{
+		{Name: "vtol_state", Type: "uint8_t"},
+		{Name: "landed_state", Type: "uint8_t"},
+	}},
+	{ID: 246, Name: "ADSB_VEHICLE", Fields: []mavlinkField{
+		{Name: "ICAO_address", Type: "uint32_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "altitude_type", Type: "uint8_t"},
+		{Name: "altitude", Type: "int32_t"},
+		{Name: "heading", Type: "uint16_t"},
+		{Name: "hor_velocity", Type: "uint16_t"},
+		{Name: "ver_velocity", Type: "int16_t"},
+		{Name: "callsign", Type: "char", ArrayLen: 9},
+		{Name: "emitter_type", Type: "uint8_t"},
+		{Name: "tslc", Type: "uint8_t"},
+		{Name: "flags", Type: "uint16_t"},
+		{Name: "squawk", Type: "uint16_t"},
+	}},
+	{ID: 247, Name: "COLLISION", Fields: []mavlinkField{
+		{Name: "src", Type: "uint8_t"},
+		{Name: "id", Type: "uint32_t"},
+		{Name: "action", Type: "uint8_t"},
+		{Name: "threat_level", Type: "uint8_t"},
+		{Name: "time_to_minimum_delta", Type: "float"},
+		{Name: "altitude_minimum_delta", Type: "float"},
+		{Name: "horizontal_minimum_delta", Type: "float"},
+	}},
+	{ID: 248, Name: "V2_EXTENSION", Fields: []mavlinkField{
+		{Name: "target_network", Type: "uint8_t"},
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "message_type", Type: "uint16_t"},
+		{Name: "payload", Type: "uint8_t", ArrayLen: 249},
+	}},
+	{ID: 249, Name: "MEMORY_VECT", Fields: []mavlinkField{
+		{Name: "address", Type: "uint16_t"},
+		{Name: "ver", Type: "uint8_t"},
+		{Name: "type", Type: "uint8_t"},
+		{Name: "value", Type: "int8_t", ArrayLen: 32},
+	}},
+	{ID: 250, Name: "DEBUG_VECT", Fields: []mavlinkField{
+		{Name: "name", Type: "char", ArrayLen: 10},
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+	}},
+	{ID: 251, Name: "NAMED_VALUE_FLOAT", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "name", Type: "char", ArrayLen: 10},
+		{Name: "value", Type: "float"},
+	}},
+	{ID: 252, Name: "NAMED_VALUE_INT", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "name", Type: "char", ArrayLen: 10},
+		{Name: "value", Type: "int32_t"},
+	}},
+	{ID: 253, Name: "STATUSTEXT", Fields: []mavlinkField{
+		{Name: "severity", Type: "uint8_t"},
+		{Name: "text", Type: "char", ArrayLen: 50},
+		{Name: "id", Type: "uint16_t", Extension: true},
+		{Name: "chunk_seq", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 254, Name: "DEBUG", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "ind", Type: "uint8_t"},
+		{Name: "value", Type: "float"},
+	}},
+	{ID: 256, Name: "SETUP_SIGNING", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "secret_key", Type: "uint8_t", ArrayLen: 32},
+		{Name: "initial_timestamp", Type: "uint64_t"},
+	}},
+	{ID: 257, Name: "BUTTON_CHANGE", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "last_change_ms", Type: "uint32_t"},
+		{Name: "state", Type: "uint8_t"},
+	}},
+	{ID: 258, Name: "PLAY_TUNE", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "tune", Type: "char", ArrayLen: 30},
+		{Name: "tune2", Type: "char", ArrayLen: 200, Extension: true},
+	}},
+	{ID: 259, Name: "CAMERA_INFORMATION", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "vendor_name", Type: "uint8_t", ArrayLen: 32},
+		{Name: "model_name", Type: "uint8_t", ArrayLen: 32},
+		{Name: "firmware_version", Type: "uint32_t"},
+		{Name: "focal_length", Type: "float"},
+		{Name: "sensor_size_h", Type: "float"},
+		{Name: "sensor_size_v", Type: "float"},
+		{Name: "resolution_h", Type: "uint16_t"},
+		{Name: "resolution_v", Type: "uint16_t"},
+		{Name: "lens_id", Type: "uint8_t"},
+		{Name: "flags", Type: "uint32_t"},
+		{Name: "cam_definition_version", Type: "uint16_t"},
+		{Name: "cam_definition_uri", Type: "char", ArrayLen: 140},
+		{Name: "gimbal_device_id", Type: "uint8_t", Extension: true},
+		{Name: "camera_device_id", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 260, Name: "CAMERA_SETTINGS", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "mode_id", Type: "uint8_t"},
+		{Name: "zoomLevel", Type: "float", Extension: true},
+		{Name: "focusLevel", Type: "float", Extension: true},
+		{Name: "camera_device_id", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 261, Name: "STORAGE_INFORMATION", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "storage_id", Type: "uint8_t"},
+		{Name: "storage_count", Type: "uint8_t"},
+		{Name: "status", Type: "uint8_t"},
+		{Name: "total_capacity", Type: "float"},
+		{Name: "used_capacity", Type: "float"},
+		{Name: "available_capacity", Type: "float"},
+		{Name: "read_speed", Type: "float"},
+		{Name: "write_speed", Type: "float"},
+		{Name: "type", Type: "uint8_t", Extension: true},
+		{Name: "name", Type: "char", ArrayLen: 32, Extension: true},
+		{Name: "storage_usage", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 262, Name: "CAMERA_CAPTURE_STATUS", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "image_status", Type: "uint8_t"},
+		{Name: "video_status", Type: "uint8_t"},
+		{Name: "image_interval", Type: "float"},
+		{Name: "recording_time_ms", Type: "uint32_t"},
+		{Name: "available_capacity", Type: "float"},
+		{Name: "image_count", Type: "int32_t", Extension: true},
+		{Name: "camera_device_id", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 263, Name: "CAMERA_IMAGE_CAPTURED", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "time_utc", Type: "uint64_t"},
+		{Name: "camera_id", Type: "uint8_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "alt", Type: "int32_t"},
+		{Name: "relative_alt", Type: "int32_t"},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "image_index", Type: "int32_t"},
+		{Name: "capture_result", Type: "int8_t"},
+		{Name: "file_url", Type: "char", ArrayLen: 205},
+	}},
+	{ID: 264, Name: "FLIGHT_INFORMATION", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "arming_time_utc", Type: "uint64_t"},
+		{Name: "takeoff_time_utc", Type: "uint64_t"},
+		{Name: "flight_uuid", Type: "uint64_t"},
+		{Name: "landing_time", Type: "uint32_t", Extension: true},
+	}},
+	{ID: 265, Name: "MOUNT_ORIENTATION", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "yaw_absolute", Type: "float", Extension: true},
+	}},
+	{ID: 266, Name: "LOGGING_DATA", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "sequence", Type: "uint16_t"},
+		{Name: "length", Type: "uint8_t"},
+		{Name: "first_message_offset", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 249},
+	}},
+	{ID: 267, Name: "LOGGING_DATA_ACKED", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "sequence", Type: "uint16_t"},
+		{Name: "length", Type: "uint8_t"},
+		{Name: "first_message_offset", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 249},
+	}},
+	{ID: 268, Name: "LOGGING_ACK", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "sequence", Type: "uint16_t"},
+	}},
+	{ID: 269, Name: "VIDEO_STREAM_INFORMATION", Fields: []mavlinkField{
+		{Name: "stream_id", Type: "uint8_t"},
+		{Name: "count", Type: "uint8_t"},
+		{Name: "type", Type: "uint8_t"},
+		{Name: "flags", Type: "uint16_t"},
+		{Name: "framerate", Type: "float"},
+		{Name: "resolution_h", Type: "uint16_t"},
+		{Name: "resolution_v", Type: "uint16_t"},
+		{Name: "bitrate", Type: "uint32_t"},
+		{Name: "rotation", Type: "uint16_t"},
+		{Name: "hfov", Type: "uint16_t"},
+		{Name: "name", Type: "char", ArrayLen: 32},
+		{Name: "uri", Type: "char", ArrayLen: 160},
+		{Name: "encoding", Type: "uint8_t", Extension: true},
+		{Name: "camera_device_id", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 270, Name: "VIDEO_STREAM_STATUS", Fields: []mavlinkField{
+		{Name: "stream_id", Type: "uint8_t"},
+		{Name: "flags", Type: "uint16_t"},
+		{Name: "framerate", Type: "float"},
+		{Name: "resolution_h", Type: "uint16_t"},
+		{Name: "resolution_v", Type: "uint16_t"},
+		{Name: "bitrate", Type: "uint32_t"},
+		{Name: "rotation", Type: "uint16_t"},
+		{Name: "hfov", Type: "uint16_t"},
+		{Name: "camera_device_id", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 271, Name: "CAMERA_FOV_STATUS", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "lat_camera", Type: "int32_t"},
+		{Name: "lon_camera", Type: "int32_t"},
+		{Name: "alt_camera", Type: "int32_t"},
+		{Name: "lat_image", Type: "int32_t"},
+		{Name: "lon_image", Type: "int32_t"},
+		{Name: "alt_image", Type: "int32_t"},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "hfov", Type: "float"},
+		{Name: "vfov", Type: "float"},
+		{Name: "camera_device_id", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 275, Name: "CAMERA_TRACKING_IMAGE_STATUS", Fields: []mavlinkField{
+		{Name: "tracking_status", Type: "uint8_t"},
+		{Name: "tracking_mode", Type: "uint8_t"},
+		{Name: "target_data", Type: "uint8_t"},
+		{Name: "point_x", Type: "float"},
+		{Name: "point_y", Type: "float"},
+		{Name: "radius", Type: "float"},
+		{Name: "rec_top_x", Type: "float"},
+		{Name: "rec_top_y", Type: "float"},
+		{Name: "rec_bottom_x", Type: "float"},
+		{Name: "rec_bottom_y", Type: "float"},
+		{Name: "camera_device_id", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 276, Name: "CAMERA_TRACKING_GEO_STATUS", Fields: []mavlinkField{
+		{Name: "tracking_status", Type: "uint8_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "alt", Type: "float"},
+		{Name: "h_acc", Type: "float"},
+		{Name: "v_acc", Type: "float"},
+		{Name: "vel_n", Type: "float"},
+		{Name: "vel_e", Type: "float"},
+		{Name: "vel_d", Type: "float"},
+		{Name: "vel_acc", Type: "float"},
+		{Name: "dist", Type: "float"},
+		{Name: "hdg", Type: "float"},
+		{Name: "hdg_acc", Type: "float"},
+		{Name: "camera_device_id", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 277, Name: "CAMERA_THERMAL_RANGE", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "stream_id", Type: "uint8_t"},
+		{Name: "camera_device_id", Type: "uint8_t"},
+		{Name: "max", Type: "float"},
+		{Name: "max_point_x", Type: "float"},
+		{Name: "max_point_y", Type: "float"},
+		{Name: "min", Type: "float"},
+		{Name: "min_point_x", Type: "float"},
+		{Name: "min_point_y", Type: "float"},
+	}},
+	{ID: 280, Name: "GIMBAL_MANAGER_INFORMATION", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "cap_flags", Type: "uint32_t"},
+		{Name: "gimbal_device_id", Type: "uint8_t"},
+		{Name: "roll_min", Type: "float"},
+		{Name: "roll_max", Type: "float"},
+		{Name: "pitch_min", Type: "float"},
+		{Name: "pitch_max", Type: "float"},
+		{Name: "yaw_min", Type: "float"},
+		{Name: "yaw_max", Type: "float"},
+	}},
+	{ID: 281, Name: "GIMBAL_MANAGER_STATUS", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "flags", Type: "uint32_t"},
+		{Name: "gimbal_device_id", Type: "uint8_t"},
+		{Name: "primary_control_sysid", Type: "uint8_t"},
+		{Name: "primary_control_compid", Type: "uint8_t"},
+		{Name: "secondary_control_sysid", Type: "uint8_t"},
+		{Name: "secondary_control_compid", Type: "uint8_t"},
+	}},
+	{ID: 282, Name: "GIMBAL_MANAGER_SET_ATTITUDE", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "flags", Type: "uint32_t"},
+		{Name: "gimbal_device_id", Type: "uint8_t"},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "angular_velocity_x", Type: "float"},
+		{Name: "angular_velocity_y", Type: "float"},
+		{Name: "angular_velocity_z", Type: "float"},
+	}},
+	{ID: 283, Name: "GIMBAL_DEVICE_INFORMATION", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "vendor_name", Type: "char", ArrayLen: 32},
+		{Name: "model_name", Type: "char", ArrayLen: 32},
+		{Name: "custom_name", Type: "char", ArrayLen: 32},
+		{Name: "firmware_version", Type: "uint32_t"},
+		{Name: "hardware_version", Type: "uint32_t"},
+		{Name: "uid", Type: "uint64_t"},
+		{Name: "cap_flags", Type: "uint16_t"},
+		{Name: "custom_cap_flags", Type: "uint16_t"},
+		{Name: "roll_min", Type: "float"},
+		{Name: "roll_max", Type: "float"},
+		{Name: "pitch_min", Type: "float"},
+		{Name: "pitch_max", Type: "float"},
+		{Name: "yaw_min", Type: "float"},
+		{Name: "yaw_max", Type: "float"},
+		{Name: "gimbal_device_id", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 284, Name: "GIMBAL_DEVICE_SET_ATTITUDE", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "flags", Type: "uint16_t"},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "angular_velocity_x", Type: "float"},
+		{Name: "angular_velocity_y", Type: "float"},
+		{Name: "angular_velocity_z", Type: "float"},
+	}},
+	{ID: 285, Name: "GIMBAL_DEVICE_ATTITUDE_STATUS", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "flags", Type: "uint16_t"},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "angular_velocity_x", Type: "float"},
+		{Name: "angular_velocity_y", Type: "float"},
+		{Name: "angular_velocity_z", Type: "float"},
+		{Name: "failure_flags", Type: "uint32_t"},
+		{Name: "delta_yaw", Type: "float", Extension: true},
+		{Name: "delta_yaw_velocity", Type: "float", Extension: true},
+		{Name: "gimbal_device_id", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 286, Name: "AUTOPILOT_STATE_FOR_GIMBAL_DEVICE", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "time_boot_us", Type: "uint64_t"},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "q_estimated_delay_us", Type: "uint32_t"},
+		{Name: "vx", Type: "float"},
+		{Name: "vy", Type: "float"},
+		{Name: "vz", Type: "float"},
+		{Name: "v_estimated_delay_us", Type: "uint32_t"},
+		{Name: "feed_forward_angular_velocity_z", Type: "float"},
+		{Name: "estimator_status", Type: "uint16_t"},
+		{Name: "landed_state", Type: "uint8_t"},
+		{Name: "angular_velocity_z", Type: "float", Extension: true},
+	}},
+	{ID: 287, Name: "GIMBAL_MANAGER_SET_PITCHYAW", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "flags", Type: "uint32_t"},
+		{Name: "gimbal_device_id", Type: "uint8_t"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "pitch_rate", Type: "float"},
+		{Name: "yaw_rate", Type: "float"},
+	}},
+	{ID: 288, Name: "GIMBAL_MANAGER_SET_MANUAL_CONTROL", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "flags", Type: "uint32_t"},
+		{Name: "gimbal_device_id", Type: "uint8_t"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "pitch_rate", Type: "float"},
+		{Name: "yaw_rate", Type: "float"},
+	}},
+	{ID: 290, Name: "ESC_INFO", Fields: []mavlinkField{
+		{Name: "index", Type: "uint8_t"},
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "counter", Type: "uint16_t"},
+		{Name: "count", Type: "uint8_t"},
+		{Name: "connection_type", Type: "uint8_t"},
+		{Name: "info", Type: "uint8_t"},
+		{Name: "failure_flags", Type: "uint16_t", ArrayLen: 4},
+		{Name: "error_count", Type: "uint32_t", ArrayLen: 4},
+		{Name: "temperature", Type: "int16_t", ArrayLen: 4},
+	}},
+	{ID: 291, Name: "ESC_STATUS", Fields: []mavlinkField{
+		{Name: "index", Type: "uint8_t"},
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "rpm", Type: "int32_t", ArrayLen: 4},
+		{Name: "voltage", Type: "float", ArrayLen: 4},
+		{Name: "current", Type: "float", ArrayLen: 4},
+	}},
+	{ID: 299, Name: "WIFI_CONFIG_AP", Fields: []mavlinkField{
+		{Name: "ssid", Type: "char", ArrayLen: 32},
+		{Name: "password", Type: "char", ArrayLen: 64},
+		{Name: "mode", Type: "int8_t", Extension: true},
+		{Name: "response", Type: "int8_t", Extension: true},
+	}},
+	{ID: 300, Name: "PROTOCOL_VERSION", Fields: []mavlinkField{
+		{Name: "version", Type: "uint16_t"},
+		{Name: "min_version", Type: "uint16_t"},
+		{Name: "max_version", Type: "uint16_t"},
+		{Name: "spec_version_hash", Type: "uint8_t", ArrayLen: 8},
+		{Name: "library_version_hash", Type: "uint8_t", ArrayLen: 8},
+	}},
+	{ID: 301, Name: "AIS_VESSEL", Fields: []mavlinkField{
+		{Name: "MMSI", Type: "uint32_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "COG", Type: "uint16_t"},
+		{Name: "heading", Type: "uint16_t"},
+		{Name: "velocity", Type: "uint16_t"},
+		{Name: "turn_rate", Type: "int8_t"},
+		{Name: "navigational_status", Type: "uint8_t"},
+		{Name: "type", Type: "uint8_t"},
+		{Name: "dimension_bow", Type: "uint16_t"},
+		{Name: "dimension_stern", Type: "uint16_t"},
+		{Name: "dimension_port", Type: "uint8_t"},
+		{Name: "dimension_starboard", Type: "uint8_t"},
+		{Name: "callsign", Type: "char", ArrayLen: 7},
+		{Name: "name", Type: "char", ArrayLen: 20},
+		{Name: "tslc", Type: "uint16_t"},
+		{Name: "flags", Type: "uint16_t"},
+	}},
+	{ID: 310, Name: "UAVCAN_NODE_STATUS", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "uptime_sec", Type: "uint32_t"},
+		{Name: "health", Type: "uint8_t"},
+		{Name: "mode", Type: "uint8_t"},
+		{Name: "sub_mode", Type: "uint8_t"},
+		{Name: "vendor_specific_status_code", Type: "uint16_t"},
+	}},
+	{ID: 311, Name: "UAVCAN_NODE_INFO", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "uptime_sec", Type: "uint32_t"},
+		{Name: "name", Type: "char", ArrayLen: 80},
+		{Name: "hw_version_major", Type: "uint8_t"},
+		{Name: "hw_version_minor", Type: "uint8_t"},
+		{Name: "hw_unique_id", Type: "uint8_t", ArrayLen: 16},
+		{Name: "sw_version_major", Type: "uint8_t"},
+		{Name: "sw_version_minor", Type: "uint8_t"},
+		{Name: "sw_vcs_commit", Type: "uint32_t"},
+	}},
+	{ID: 320, Name: "PARAM_EXT_REQUEST_READ", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "param_id", Type: "char", ArrayLen: 16},
+		{Name: "param_index", Type: "int16_t"},
+	}},
+	{ID: 321, Name: "PARAM_EXT_REQUEST_LIST", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+	}},
+	{ID: 322, Name: "PARAM_EXT_VALUE", Fields: []mavlinkField{
+		{Name: "param_id", Type: "char", ArrayLen: 16},
+		{Name: "param_value", Type: "char", ArrayLen: 128},
+		{Name: "param_type", Type: "uint8_t"},
+		{Name: "param_count", Type: "uint16_t"},
+		{Name: "param_index", Type: "uint16_t"},
+	}},
+	{ID: 323, Name: "PARAM_EXT_SET", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "param_id", Type: "char", ArrayLen: 16},
+		{Name: "param_value", Type: "char", ArrayLen: 128},
+		{Name: "param_type", Type: "uint8_t"},
+	}},
+	{ID: 324, Name: "PARAM_EXT_ACK", Fields: []mavlinkField{
+		{Name: "param_id", Type: "char", ArrayLen: 16},
+		{Name: "param_value", Type: "char", ArrayLen: 128},
+		{Name: "param_type", Type: "uint8_t"},
+		{Name: "param_result", Type: "uint8_t"},
+	}},
+	{ID: 330, Name: "OBSTACLE_DISTANCE", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "sensor_type", Type: "uint8_t"},
+		{Name: "distances", Type: "uint16_t", ArrayLen: 72},
+		{Name: "increment", Type: "uint8_t"},
+		{Name: "min_distance", Type: "uint16_t"},
+		{Name: "max_distance", Type: "uint16_t"},
+		{Name: "increment_f", Type: "float", Extension: true},
+		{Name: "angle_offset", Type: "float", Extension: true},
+		{Name: "frame", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 331, Name: "ODOMETRY", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "frame_id", Type: "uint8_t"},
+		{Name: "child_frame_id", Type: "uint8_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "q", Type: "float", ArrayLen: 4},
+		{Name: "vx", Type: "float"},
+		{Name: "vy", Type: "float"},
+		{Name: "vz", Type: "float"},
+		{Name: "rollspeed", Type: "float"},
+		{Name: "pitchspeed", Type: "float"},
+		{Name: "yawspeed", Type: "float"},
+		{Name: "pose_covariance", Type: "float", ArrayLen: 21},
+		{Name: "velocity_covariance", Type: "float", ArrayLen: 21},
+		{Name: "reset_counter", Type: "uint8_t", Extension: true},
+		{Name: "estimator_type", Type: "uint8_t", Extension: true},
+		{Name: "quality", Type: "int8_t", Extension: true},
+	}},
+	{ID: 332, Name: "TRAJECTORY_REPRESENTATION_WAYPOINTS", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "valid_points", Type: "uint8_t"},
+		{Name: "pos_x", Type: "float", ArrayLen: 5},
+		{Name: "pos_y", Type: "float", ArrayLen: 5},
+		{Name: "pos_z", Type: "float", ArrayLen: 5},
+		{Name: "vel_x", Type: "float", ArrayLen: 5},
+		{Name: "vel_y", Type: "float", ArrayLen: 5},
+		{Name: "vel_z", Type: "float", ArrayLen: 5},
+		{Name: "acc_x", Type: "float", ArrayLen: 5},
+		{Name: "acc_y", Type: "float", ArrayLen: 5},
+		{Name: "acc_z", Type: "float", ArrayLen: 5},
+		{Name: "pos_yaw", Type: "float", ArrayLen: 5},
+		{Name: "vel_yaw", Type: "float", ArrayLen: 5},
+		{Name: "command", Type: "uint16_t", ArrayLen: 5},
+	}},
+	{ID: 333, Name: "TRAJECTORY_REPRESENTATION_BEZIER", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "valid_points", Type: "uint8_t"},
+		{Name: "pos_x", Type: "float", ArrayLen: 5},
+		{Name: "pos_y", Type: "float", ArrayLen: 5},
+		{Name: "pos_z", Type: "float", ArrayLen: 5},
+		{Name: "delta", Type: "float", ArrayLen: 5},
+		{Name: "pos_yaw", Type: "float", ArrayLen: 5},
+	}},
+	{ID: 334, Name: "CELLULAR_STATUS", Fields: []mavlinkField{
+		{Name: "status", Type: "uint8_t"},
+		{Name: "failure_reason", Type: "uint8_t"},
+		{Name: "type", Type: "uint8_t"},
+		{Name: "quality", Type: "uint8_t"},
+		{Name: "mcc", Type: "uint16_t"},
+		{Name: "mnc", Type: "uint16_t"},
+		{Name: "lac", Type: "uint16_t"},
+	}},
+	{ID: 335, Name: "ISBD_LINK_STATUS", Fields: []mavlinkField{
+		{Name: "timestamp", Type: "uint64_t"},
+		{Name: "last_heartbeat", Type: "uint64_t"},
+		{Name: "failed_sessions", Type: "uint16_t"},
+		{Name: "successful_sessions", Type: "uint16_t"},
+		{Name: "signal_quality", Type: "uint8_t"},
+		{Name: "ring_pending", Type: "uint8_t"},
+		{Name: "tx_session_pending", Type: "uint8_t"},
+		{Name: "rx_session_pending", Type: "uint8_t"},
+	}},
+	{ID: 336, Name: "CELLULAR_CONFIG", Fields: []mavlinkField{
+		{Name: "enable_lte", Type: "uint8_t"},
+		{Name: "enable_pin", Type: "uint8_t"},
+		{Name: "pin", Type: "char", ArrayLen: 16},
+		{Name: "new_pin", Type: "char", ArrayLen: 16},
+		{Name: "apn", Type: "char", ArrayLen: 32},
+		{Name: "puk", Type: "char", ArrayLen: 16},
+		{Name: "roaming", Type: "uint8_t"},
+		{Name: "response", Type: "uint8_t"},
+	}},
+	{ID: 339, Name: "RAW_RPM", Fields: []mavlinkField{
+		{Name: "index", Type: "uint8_t"},
+		{Name: "frequency", Type: "float"},
+	}},
+	{ID: 340, Name: "UTM_GLOBAL_POSITION", Fields: []mavlinkField{
+		{Name: "time", Type: "uint64_t"},
+		{Name: "uas_id", Type: "uint8_t", ArrayLen: 18},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lon", Type: "int32_t"},
+		{Name: "alt", Type: "int32_t"},
+		{Name: "relative_alt", Type: "int32_t"},
+		{Name: "vx", Type: "int16_t"},
+		{Name: "vy", Type: "int16_t"},
+		{Name: "vz", Type: "int16_t"},
+		{Name: "h_acc", Type: "uint16_t"},
+		{Name: "v_acc", Type: "uint16_t"},
+		{Name: "vel_acc", Type: "uint16_t"},
+		{Name: "next_lat", Type: "int32_t"},
+		{Name: "next_lon", Type: "int32_t"},
+		{Name: "next_alt", Type: "int32_t"},
+		{Name: "update_rate", Type: "uint16_t"},
+		{Name: "flight_state", Type: "uint8_t"},
+		{Name: "flags", Type: "uint8_t"},
+	}},
+	{ID: 345, Name: "PARAM_ERROR", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "param_id", Type: "char", ArrayLen: 16},
+		{Name: "param_index", Type: "int16_t"},
+		{Name: "error", Type: "uint8_t"},
+	}},
+	{ID: 350, Name: "DEBUG_FLOAT_ARRAY", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "name", Type: "char", ArrayLen: 10},
+		{Name: "array_id", Type: "uint16_t"},
+		{Name: "data", Type: "float", ArrayLen: 58, Extension: true},
+	}},
+	{ID: 360, Name: "ORBIT_EXECUTION_STATUS", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "radius", Type: "float"},
+		{Name: "frame", Type: "uint8_t"},
+		{Name: "x", Type: "int32_t"},
+		{Name: "y", Type: "int32_t"},
+		{Name: "z", Type: "float"},
+	}},
+	{ID: 370, Name: "SMART_BATTERY_INFO", Fields: []mavlinkField{
+		{Name: "id", Type: "uint8_t"},
+		{Name: "battery_function", Type: "uint8_t"},
+		{Name: "type", Type: "uint8_t"},
+		{Name: "capacity_full_specification", Type: "int32_t"},
+		{Name: "capacity_full", Type: "int32_t"},
+		{Name: "cycle_count", Type: "uint16_t"},
+		{Name: "serial_number", Type: "char", ArrayLen: 16},
+		{Name: "device_name", Type: "char", ArrayLen: 50},
+		{Name: "weight", Type: "uint16_t"},
+		{Name: "discharge_minimum_voltage", Type: "uint16_t"},
+		{Name: "charging_minimum_voltage", Type: "uint16_t"},
+		{Name: "resting_minimum_voltage", Type: "uint16_t"},
+		{Name: "charging_maximum_voltage", Type: "uint16_t", Extension: true},
+		{Name: "cells_in_series", Type: "uint8_t", Extension: true},
+		{Name: "discharge_maximum_current", Type: "uint32_t", Extension: true},
+		{Name: "discharge_maximum_burst_current", Type: "uint32_t", Extension: true},
+		{Name: "manufacture_date", Type: "char", ArrayLen: 11, Extension: true},
+	}},
+	{ID: 371, Name: "FUEL_STATUS", Fields: []mavlinkField{
+		{Name: "id", Type: "uint8_t"},
+		{Name: "maximum_fuel", Type: "float"},
+		{Name: "consumed_fuel", Type: "float"},
+		{Name: "remaining_fuel", Type: "float"},
+		{Name: "percent_remaining", Type: "uint8_t"},
+		{Name: "flow_rate", Type: "float"},
+		{Name: "temperature", Type: "float"},
+		{Name: "fuel_type", Type: "uint32_t"},
+	}},
+	{ID: 372, Name: "BATTERY_INFO", Fields: []mavlinkField{
+		{Name: "id", Type: "uint8_t"},
+		{Name: "battery_function", Type: "uint8_t"},
+		{Name: "type", Type: "uint8_t"},
+		{Name: "state_of_health", Type: "uint8_t"},
+		{Name: "cells_in_series", Type: "uint8_t"},
+		{Name: "cycle_count", Type: "uint16_t"},
+		{Name: "weight", Type: "uint16_t"},
+		{Name: "discharge_minimum_voltage", Type: "float"},
+		{Name: "charging_minimum_voltage", Type: "float"},
+		{Name: "resting_minimum_voltage", Type: "float"},
+		{Name: "charging_maximum_voltage", Type: "float"},
+		{Name: "charging_maximum_current", Type: "float"},
+		{Name: "nominal_voltage", Type: "float"},
+		{Name: "discharge_maximum_current", Type: "float"},
+		{Name: "discharge_maximum_burst_current", Type: "float"},
+		{Name: "design_capacity", Type: "float"},
+		{Name: "full_charge_capacity", Type: "float"},
+		{Name: "manufacture_date", Type: "char", ArrayLen: 9},
+		{Name: "serial_number", Type: "char", ArrayLen: 32},
+		{Name: "name", Type: "char", ArrayLen: 50},
+	}},
+	{ID: 373, Name: "GENERATOR_STATUS", Fields: []mavlinkField{
+		{Name: "status", Type: "uint64_t"},
+		{Name: "generator_speed", Type: "uint16_t"},
+		{Name: "battery_current", Type: "float"},
+		{Name: "load_current", Type: "float"},
+		{Name: "power_generated", Type: "float"},
+		{Name: "bus_voltage", Type: "float"},
+		{Name: "rectifier_temperature", Type: "int16_t"},
+		{Name: "bat_current_setpoint", Type: "float"},
+		{Name: "generator_temperature", Type: "int16_t"},
+		{Name: "runtime", Type: "uint32_t"},
+		{Name: "time_until_maintenance", Type: "int32_t"},
+	}},
+	{ID: 375, Name: "ACTUATOR_OUTPUT_STATUS", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "active", Type: "uint32_t"},
+		{Name: "actuator", Type: "float", ArrayLen: 32},
+	}},
+	{ID: 380, Name: "TIME_ESTIMATE_TO_TARGET", Fields: []mavlinkField{
+		{Name: "safe_return", Type: "int32_t"},
+		{Name: "land", Type: "int32_t"},
+		{Name: "mission_next_item", Type: "int32_t"},
+		{Name: "mission_end", Type: "int32_t"},
+		{Name: "commanded_action", Type: "int32_t"},
+	}},
+	{ID: 385, Name: "TUNNEL", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "payload_type", Type: "uint16_t"},
+		{Name: "payload_length", Type: "uint8_t"},
+		{Name: "payload", Type: "uint8_t", ArrayLen: 128},
+	}},
+	{ID: 386, Name: "CAN_FRAME", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "bus", Type: "uint8_t"},
+		{Name: "len", Type: "uint8_t"},
+		{Name: "id", Type: "uint32_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 8},
+	}},
+	{ID: 387, Name: "CANFD_FRAME", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "bus", Type: "uint8_t"},
+		{Name: "len", Type: "uint8_t"},
+		{Name: "id", Type: "uint32_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 64},
+	}},
+	{ID: 388, Name: "CAN_FILTER_MODIFY", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "bus", Type: "uint8_t"},
+		{Name: "operation", Type: "uint8_t"},
+		{Name: "num_ids", Type: "uint8_t"},
+		{Name: "ids", Type: "uint16_t", ArrayLen: 16},
+	}},
+	{ID: 390, Name: "ONBOARD_COMPUTER_STATUS", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "uptime", Type: "uint32_t"},
+		{Name: "type", Type: "uint8_t"},
+		{Name: "cpu_cores", Type: "uint8_t", ArrayLen: 8},
+		{Name: "cpu_combined", Type: "uint8_t", ArrayLen: 10},
+		{Name: "gpu_cores", Type: "uint8_t", ArrayLen: 4},
+		{Name: "gpu_combined", Type: "uint8_t", ArrayLen: 10},
+		{Name: "temperature_board", Type: "int8_t"},
+		{Name: "temperature_core", Type: "int8_t", ArrayLen: 8},
+		{Name: "fan_speed", Type: "int16_t", ArrayLen: 4},
+		{Name: "ram_usage", Type: "uint32_t"},
+		{Name: "ram_total", Type: "uint32_t"},
+		{Name: "storage_type", Type: "uint32_t", ArrayLen: 4},
+		{Name: "storage_usage", Type: "uint32_t", ArrayLen: 4},
+		{Name: "storage_total", Type: "uint32_t", ArrayLen: 4},
+		{Name: "link_type", Type: "uint32_t", ArrayLen: 6},
+		{Name: "link_tx_rate", Type: "uint32_t", ArrayLen: 6},
+		{Name: "link_rx_rate", Type: "uint32_t", ArrayLen: 6},
+		{Name: "link_tx_max", Type: "uint32_t", ArrayLen: 6},
+		{Name: "link_rx_max", Type: "uint32_t", ArrayLen: 6},
+		{Name: "status_flags", Type: "uint16_t", Extension: true},
+	}},
+	{ID: 395, Name: "COMPONENT_INFORMATION", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "general_metadata_file_crc", Type: "uint32_t"},
+		{Name: "general_metadata_uri", Type: "char", ArrayLen: 100},
+		{Name: "peripherals_metadata_file_crc", Type: "uint32_t"},
+		{Name: "peripherals_metadata_uri", Type: "char", ArrayLen: 100},
+	}},
+	{ID: 396, Name: "COMPONENT_INFORMATION_BASIC", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "capabilities", Type: "uint64_t"},
+		{Name: "time_manufacture_s", Type: "uint32_t"},
+		{Name: "vendor_name", Type: "char", ArrayLen: 32},
+		{Name: "model_name", Type: "char", ArrayLen: 32},
+		{Name: "software_version", Type: "char", ArrayLen: 24},
+		{Name: "hardware_version", Type: "char", ArrayLen: 24},
+		{Name: "serial_number", Type: "char", ArrayLen: 32},
+	}},
+	{ID: 397, Name: "COMPONENT_METADATA", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "file_crc", Type: "uint32_t"},
+		{Name: "uri", Type: "char", ArrayLen: 100},
+	}},
+	{ID: 400, Name: "PLAY_TUNE_V2", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "format", Type: "uint32_t"},
+		{Name: "tune", Type: "char", ArrayLen: 248},
+	}},
+	{ID: 401, Name: "SUPPORTED_TUNES", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "format", Type: "uint32_t"},
+	}},
+	{ID: 410, Name: "EVENT", Fields: []mavlinkField{
+		{Name: "destination_component", Type: "uint8_t"},
+		{Name: "destination_system", Type: "uint8_t"},
+		{Name: "id", Type: "uint32_t"},
+		{Name: "event_time_boot_ms", Type: "uint32_t"},
+		{Name: "sequence", Type: "uint16_t"},
+		{Name: "log_levels", Type: "uint8_t"},
+		{Name: "arguments", Type: "uint8_t", ArrayLen: 40},
+	}},
+	{ID: 411, Name: "CURRENT_EVENT_SEQUENCE", Fields: []mavlinkField{
+		{Name: "sequence", Type: "uint16_t"},
+		{Name: "flags", Type: "uint8_t"},
+	}},
+	{ID: 412, Name: "REQUEST_EVENT", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "first_sequence", Type: "uint16_t"},
+		{Name: "last_sequence", Type: "uint16_t"},
+	}},
+	{ID: 413, Name: "RESPONSE_EVENT_ERROR", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "sequence", Type: "uint16_t"},
+		{Name: "sequence_oldest_available", Type: "uint16_t"},
+		{Name: "reason", Type: "uint8_t"},
+	}},
+	{ID: 435, Name: "AVAILABLE_MODES", Fields: []mavlinkField{
+		{Name: "number_modes", Type: "uint8_t"},
+		{Name: "mode_index", Type: "uint8_t"},
+		{Name: "standard_mode", Type: "uint8_t"},
+		{Name: "custom_mode", Type: "uint32_t"},
+		{Name: "properties", Type: "uint32_t"},
+		{Name: "mode_name", Type: "char", ArrayLen: 35},
+	}},
+	{ID: 436, Name: "CURRENT_MODE", Fields: []mavlinkField{
+		{Name: "standard_mode", Type: "uint8_t"},
+		{Name: "custom_mode", Type: "uint32_t"},
+		{Name: "intended_custom_mode", Type: "uint32_t"},
+	}},
+	{ID: 437, Name: "AVAILABLE_MODES_MONITOR", Fields: []mavlinkField{
+		{Name: "seq", Type: "uint8_t"},
+	}},
+	{ID: 440, Name: "ILLUMINATOR_STATUS", Fields: []mavlinkField{
+		{Name: "uptime_ms", Type: "uint32_t"},
+		{Name: "enable", Type: "uint8_t"},
+		{Name: "mode_bitmask", Type: "uint8_t"},
+		{Name: "error_status", Type: "uint32_t"},
+		{Name: "mode", Type: "uint8_t"},
+		{Name: "brightness", Type: "float"},
+		{Name: "strobe_period", Type: "float"},
+		{Name: "strobe_duty_cycle", Type: "float"},
+		{Name: "temp_c", Type: "float"},
+		{Name: "min_strobe_period", Type: "float"},
+		{Name: "max_strobe_period", Type: "float"},
+	}},
+	{ID: 9000, Name: "WHEEL_DISTANCE", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "count", Type: "uint8_t"},
+		{Name: "distance", Type: "double", ArrayLen: 16},
+	}},
+	{ID: 9005, Name: "WINCH_STATUS", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "line_length", Type: "float"},
+		{Name: "speed", Type: "float"},
+		{Name: "tension", Type: "float"},
+		{Name: "voltage", Type: "float"},
+		{Name: "current", Type: "float"},
+		{Name: "temperature", Type: "int16_t"},
+		{Name: "status", Type: "uint32_t"},
+	}},
+	{ID: 10001, Name: "UAVIONIX_ADSB_OUT_CFG", Fields: []mavlinkField{
+		{Name: "ICAO", Type: "uint32_t"},
+		{Name: "callsign", Type: "char", ArrayLen: 9},
+		{Name: "emitterType", Type: "uint8_t"},
+		{Name: "aircraftSize", Type: "uint8_t"},
+		{Name: "gpsOffsetLat", Type: "uint8_t"},
+		{Name: "gpsOffsetLon", Type: "uint8_t"},
+		{Name: "stallSpeed", Type: "uint16_t"},
+		{Name: "rfSelect", Type: "uint8_t"},
+	}},
+	{ID: 10002, Name: "UAVIONIX_ADSB_OUT_DYNAMIC", Fields: []mavlinkField{
+		{Name: "utcTime", Type: "uint32_t"},
+		{Name: "gpsLat", Type: "int32_t"},
+		{Name: "gpsLon", Type: "int32_t"},
+		{Name: "gpsAlt", Type: "int32_t"},
+		{Name: "gpsFix", Type: "uint8_t"},
+		{Name: "numSats", Type: "uint8_t"},
+		{Name: "baroAltMSL", Type: "int32_t"},
+		{Name: "accuracyHor", Type: "uint32_t"},
+		{Name: "accuracyVert", Type: "uint16_t"},
+		{Name: "accuracyVel", Type: "uint16_t"},
+		{Name: "velVert", Type: "int16_t"},
+		{Name: "velNS", Type: "int16_t"},
+		{Name: "VelEW", Type: "int16_t"},
+		{Name: "emergencyStatus", Type: "uint8_t"},
+		{Name: "state", Type: "uint16_t"},
+		{Name: "squawk", Type: "uint16_t"},
+	}},
+	{ID: 10003, Name: "UAVIONIX_ADSB_TRANSCEIVER_HEALTH_REPORT", Fields: []mavlinkField{
+		{Name: "rfHealth", Type: "uint8_t"},
+	}},
+	{ID: 10004, Name: "UAVIONIX_ADSB_OUT_CFG_REGISTRATION", Fields: []mavlinkField{
+		{Name: "registration", Type: "char", ArrayLen: 9},
+	}},
+	{ID: 10005, Name: "UAVIONIX_ADSB_OUT_CFG_FLIGHTID", Fields: []mavlinkField{
+		{Name: "flight_id", Type: "char", ArrayLen: 9},
+	}},
+	{ID: 10006, Name: "UAVIONIX_ADSB_GET", Fields: []mavlinkField{
+		{Name: "ReqMessageId", Type: "uint32_t"},
+	}},
+	{ID: 10007, Name: "UAVIONIX_ADSB_OUT_CONTROL", Fields: []mavlinkField{
+		{Name: "state", Type: "uint8_t"},
+		{Name: "baroAltMSL", Type: "int32_t"},
+		{Name: "squawk", Type: "uint16_t"},
+		{Name: "emergencyStatus", Type: "uint8_t"},
+		{Name: "flight_id", Type: "char", ArrayLen: 8},
+		{Name: "x_bit", Type: "uint8_t"},
+	}},
+	{ID: 10008, Name: "UAVIONIX_ADSB_OUT_STATUS", Fields: []mavlinkField{
+		{Name: "state", Type: "uint8_t"},
+		{Name: "squawk", Type: "uint16_t"},
+		{Name: "NIC_NACp", Type: "uint8_t"},
+		{Name: "boardTemp", Type: "uint8_t"},
+		{Name: "fault", Type: "uint8_t"},
+		{Name: "flight_id", Type: "char", ArrayLen: 8},
+	}},
+	{ID: 10151, Name: "LOWEHEISER_GOV_EFI", Fields: []mavlinkField{
+		{Name: "volt_batt", Type: "float"},
+		{Name: "curr_batt", Type: "float"},
+		{Name: "curr_gen", Type: "float"},
+		{Name: "curr_rot", Type: "float"},
+		{Name: "fuel_level", Type: "float"},
+		{Name: "throttle", Type: "float"},
+		{Name: "runtime", Type: "uint32_t"},
+		{Name: "until_maintenance", Type: "int32_t"},
+		{Name: "rectifier_temp", Type: "float"},
+		{Name: "generator_temp", Type: "float"},
+		{Name: "efi_batt", Type: "float"},
+		{Name: "efi_rpm", Type: "float"},
+		{Name: "efi_pw", Type: "float"},
+		{Name: "efi_fuel_flow", Type: "float"},
+		{Name: "efi_fuel_consumed", Type: "float"},
+		{Name: "efi_baro", Type: "float"},
+		{Name: "efi_mat", Type: "float"},
+		{Name: "efi_clt", Type: "float"},
+		{Name: "efi_tps", Type: "float"},
+		{Name: "efi_exhaust_gas_temperature", Type: "float"},
+		{Name: "efi_index", Type: "uint8_t"},
+		{Name: "generator_status", Type: "uint16_t"},
+		{Name: "efi_status", Type: "uint16_t"},
+	}},
+	{ID: 11000, Name: "DEVICE_OP_READ", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "request_id", Type: "uint32_t"},
+		{Name: "bustype", Type: "uint8_t"},
+		{Name: "bus", Type: "uint8_t"},
+		{Name: "address", Type: "uint8_t"},
+		{Name: "busname", Type: "char", ArrayLen: 40},
+		{Name: "regstart", Type: "uint8_t"},
+		{Name: "count", Type: "uint8_t"},
+		{Name: "bank", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 11001, Name: "DEVICE_OP_READ_REPLY", Fields: []mavlinkField{
+		{Name: "request_id", Type: "uint32_t"},
+		{Name: "result", Type: "uint8_t"},
+		{Name: "regstart", Type: "uint8_t"},
+		{Name: "count", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 128},
+		{Name: "bank", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 11002, Name: "DEVICE_OP_WRITE", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "request_id", Type: "uint32_t"},
+		{Name: "bustype", Type: "uint8_t"},
+		{Name: "bus", Type: "uint8_t"},
+		{Name: "address", Type: "uint8_t"},
+		{Name: "busname", Type: "char", ArrayLen: 40},
+		{Name: "regstart", Type: "uint8_t"},
+		{Name: "count", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 128},
+		{Name: "bank", Type: "uint8_t", Extension: true},
+	}},
+	{ID: 11003, Name: "DEVICE_OP_WRITE_REPLY", Fields: []mavlinkField{
+		{Name: "request_id", Type: "uint32_t"},
+		{Name: "result", Type: "uint8_t"},
+	}},
+	{ID: 11004, Name: "SECURE_COMMAND", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "sequence", Type: "uint32_t"},
+		{Name: "operation", Type: "uint32_t"},
+		{Name: "data_length", Type: "uint8_t"},
+		{Name: "sig_length", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 220},
+	}},
+	{ID: 11005, Name: "SECURE_COMMAND_REPLY", Fields: []mavlinkField{
+		{Name: "sequence", Type: "uint32_t"},
+		{Name: "operation", Type: "uint32_t"},
+		{Name: "result", Type: "uint8_t"},
+		{Name: "data_length", Type: "uint8_t"},
+		{Name: "data", Type: "uint8_t", ArrayLen: 220},
+	}},
+	{ID: 11010, Name: "ADAP_TUNING", Fields: []mavlinkField{
+		{Name: "axis", Type: "uint8_t"},
+		{Name: "desired", Type: "float"},
+		{Name: "achieved", Type: "float"},
+		{Name: "error", Type: "float"},
+		{Name: "theta", Type: "float"},
+		{Name: "omega", Type: "float"},
+		{Name: "sigma", Type: "float"},
+		{Name: "theta_dot", Type: "float"},
+		{Name: "omega_dot", Type: "float"},
+		{Name: "sigma_dot", Type: "float"},
+		{Name: "f", Type: "float"},
+		{Name: "f_dot", Type: "float"},
+		{Name: "u", Type: "float"},
+	}},
+	{ID: 11011, Name: "VISION_POSITION_DELTA", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "time_delta_usec", Type: "uint64_t"},
+		{Name: "angle_delta", Type: "float", ArrayLen: 3},
+		{Name: "position_delta", Type: "float", ArrayLen: 3},
+		{Name: "confidence", Type: "float"},
+	}},
+	{ID: 11020, Name: "AOA_SSA", Fields: []mavlinkField{
+		{Name: "time_usec", Type: "uint64_t"},
+		{Name: "AOA", Type: "float"},
+		{Name: "SSA", Type: "float"},
+	}},
+	{ID: 11030, Name: "ESC_TELEMETRY_1_TO_4", Fields: []mavlinkField{
+		{Name: "temperature", Type: "uint8_t", ArrayLen: 4},
+		{Name: "voltage", Type: "uint16_t", ArrayLen: 4},
+		{Name: "current", Type: "uint16_t", ArrayLen: 4},
+		{Name: "totalcurrent", Type: "uint16_t", ArrayLen: 4},
+		{Name: "rpm", Type: "uint16_t", ArrayLen: 4},
+		{Name: "count", Type: "uint16_t", ArrayLen: 4},
+	}},
+	{ID: 11031, Name: "ESC_TELEMETRY_5_TO_8", Fields: []mavlinkField{
+		{Name: "temperature", Type: "uint8_t", ArrayLen: 4},
+		{Name: "voltage", Type: "uint16_t", ArrayLen: 4},
+		{Name: "current", Type: "uint16_t", ArrayLen: 4},
+		{Name: "totalcurrent", Type: "uint16_t", ArrayLen: 4},
+		{Name: "rpm", Type: "uint16_t", ArrayLen: 4},
+		{Name: "count", Type: "uint16_t", ArrayLen: 4},
+	}},
+	{ID: 11032, Name: "ESC_TELEMETRY_9_TO_12", Fields: []mavlinkField{
+		{Name: "temperature", Type: "uint8_t", ArrayLen: 4},
+		{Name: "voltage", Type: "uint16_t", ArrayLen: 4},
+		{Name: "current", Type: "uint16_t", ArrayLen: 4},
+		{Name: "totalcurrent", Type: "uint16_t", ArrayLen: 4},
+		{Name: "rpm", Type: "uint16_t", ArrayLen: 4},
+		{Name: "count", Type: "uint16_t", ArrayLen: 4},
+	}},
+	{ID: 11033, Name: "OSD_PARAM_CONFIG", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "request_id", Type: "uint32_t"},
+		{Name: "osd_screen", Type: "uint8_t"},
+		{Name: "osd_index", Type: "uint8_t"},
+		{Name: "param_id", Type: "char", ArrayLen: 16},
+		{Name: "config_type", Type: "uint8_t"},
+		{Name: "min_value", Type: "float"},
+		{Name: "max_value", Type: "float"},
+		{Name: "increment", Type: "float"},
+	}},
+	{ID: 11034, Name: "OSD_PARAM_CONFIG_REPLY", Fields: []mavlinkField{
+		{Name: "request_id", Type: "uint32_t"},
+		{Name: "result", Type: "uint8_t"},
+	}},
+	{ID: 11035, Name: "OSD_PARAM_SHOW_CONFIG", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "request_id", Type: "uint32_t"},
+		{Name: "osd_screen", Type: "uint8_t"},
+		{Name: "osd_index", Type: "uint8_t"},
+	}},
+	{ID: 11036, Name: "OSD_PARAM_SHOW_CONFIG_REPLY", Fields: []mavlinkField{
+		{Name: "request_id", Type: "uint32_t"},
+		{Name: "result", Type: "uint8_t"},
+		{Name: "param_id", Type: "char", ArrayLen: 16},
+		{Name: "config_type", Type: "uint8_t"},
+		{Name: "min_value", Type: "float"},
+		{Name: "max_value", Type: "float"},
+		{Name: "increment", Type: "float"},
+	}},
+	{ID: 11037, Name: "OBSTACLE_DISTANCE_3D", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "sensor_type", Type: "uint8_t"},
+		{Name: "frame", Type: "uint8_t"},
+		{Name: "obstacle_id", Type: "uint16_t"},
+		{Name: "x", Type: "float"},
+		{Name: "y", Type: "float"},
+		{Name: "z", Type: "float"},
+		{Name: "min_distance", Type: "float"},
+		{Name: "max_distance", Type: "float"},
+	}},
+	{ID: 11038, Name: "WATER_DEPTH", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "id", Type: "uint8_t"},
+		{Name: "healthy", Type: "uint8_t"},
+		{Name: "lat", Type: "int32_t"},
+		{Name: "lng", Type: "int32_t"},
+		{Name: "alt", Type: "float"},
+		{Name: "roll", Type: "float"},
+		{Name: "pitch", Type: "float"},
+		{Name: "yaw", Type: "float"},
+		{Name: "distance", Type: "float"},
+		{Name: "temperature", Type: "float"},
+	}},
+	{ID: 11039, Name: "MCU_STATUS", Fields: []mavlinkField{
+		{Name: "id", Type: "uint8_t"},
+		{Name: "MCU_temperature", Type: "int16_t"},
+		{Name: "MCU_voltage", Type: "uint16_t"},
+		{Name: "MCU_voltage_min", Type: "uint16_t"},
+		{Name: "MCU_voltage_max", Type: "uint16_t"},
+	}},
+	{ID: 11040, Name: "ESC_TELEMETRY_13_TO_16", Fields: []mavlinkField{
+		{Name: "temperature", Type: "uint8_t", ArrayLen: 4},
+		{Name: "voltage", Type: "uint16_t", ArrayLen: 4},
+		{Name: "current", Type: "uint16_t", ArrayLen: 4},
+		{Name: "totalcurrent", Type: "uint16_t", ArrayLen: 4},
+		{Name: "rpm", Type: "uint16_t", ArrayLen: 4},
+		{Name: "count", Type: "uint16_t", ArrayLen: 4},
+	}},
+	{ID: 11041, Name: "ESC_TELEMETRY_17_TO_20", Fields: []mavlinkField{
+		{Name: "temperature", Type: "uint8_t", ArrayLen: 4},
+		{Name: "voltage", Type: "uint16_t", ArrayLen: 4},
+		{Name: "current", Type: "uint16_t", ArrayLen: 4},
+		{Name: "totalcurrent", Type: "uint16_t", ArrayLen: 4},
+		{Name: "rpm", Type: "uint16_t", ArrayLen: 4},
+		{Name: "count", Type: "uint16_t", ArrayLen: 4},
+	}},
+	{ID: 11042, Name: "ESC_TELEMETRY_21_TO_24", Fields: []mavlinkField{
+		{Name: "temperature", Type: "uint8_t", ArrayLen: 4},
+		{Name: "voltage", Type: "uint16_t", ArrayLen: 4},
+		{Name: "current", Type: "uint16_t", ArrayLen: 4},
+		{Name: "totalcurrent", Type: "uint16_t", ArrayLen: 4},
+		{Name: "rpm", Type: "uint16_t", ArrayLen: 4},
+		{Name: "count", Type: "uint16_t", ArrayLen: 4},
+	}},
+	{ID: 11043, Name: "ESC_TELEMETRY_25_TO_28", Fields: []mavlinkField{
+		{Name: "temperature", Type: "uint8_t", ArrayLen: 4},
+		{Name: "voltage", Type: "uint16_t", ArrayLen: 4},
+		{Name: "current", Type: "uint16_t", ArrayLen: 4},
+		{Name: "totalcurrent", Type: "uint16_t", ArrayLen: 4},
+		{Name: "rpm", Type: "uint16_t", ArrayLen: 4},
+		{Name: "count", Type: "uint16_t", ArrayLen: 4},
+	}},
+	{ID: 11044, Name: "ESC_TELEMETRY_29_TO_32", Fields: []mavlinkField{
+		{Name: "temperature", Type: "uint8_t", ArrayLen: 4},
+		{Name: "voltage", Type: "uint16_t", ArrayLen: 4},
+		{Name: "current", Type: "uint16_t", ArrayLen: 4},
+		{Name: "totalcurrent", Type: "uint16_t", ArrayLen: 4},
+		{Name: "rpm", Type: "uint16_t", ArrayLen: 4},
+		{Name: "count", Type: "uint16_t", ArrayLen: 4},
+	}},
+	{ID: 11060, Name: "NAMED_VALUE_STRING", Fields: []mavlinkField{
+		{Name: "time_boot_ms", Type: "uint32_t"},
+		{Name: "name", Type: "char", ArrayLen: 10},
+		{Name: "value", Type: "char", ArrayLen: 64},
+	}},
+	{ID: 12900, Name: "OPEN_DRONE_ID_BASIC_ID", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "id_or_mac", Type: "uint8_t", ArrayLen: 20},
+		{Name: "id_type", Type: "uint8_t"},
+		{Name: "ua_type", Type: "uint8_t"},
+		{Name: "uas_id", Type: "uint8_t", ArrayLen: 20},
+	}},
+	{ID: 12901, Name: "OPEN_DRONE_ID_LOCATION", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "id_or_mac", Type: "uint8_t", ArrayLen: 20},
+		{Name: "status", Type: "uint8_t"},
+		{Name: "direction", Type: "uint16_t"},
+		{Name: "speed_horizontal", Type: "uint16_t"},
+		{Name: "speed_vertical", Type: "int16_t"},
+		{Name: "latitude", Type: "int32_t"},
+		{Name: "longitude", Type: "int32_t"},
+		{Name: "altitude_barometric", Type: "float"},
+		{Name: "altitude_geodetic", Type: "float"},
+		{Name: "height_reference", Type: "uint8_t"},
+		{Name: "height", Type: "float"},
+		{Name: "horizontal_accuracy", Type: "uint8_t"},
+		{Name: "vertical_accuracy", Type: "uint8_t"},
+		{Name: "barometer_accuracy", Type: "uint8_t"},
+		{Name: "speed_accuracy", Type: "uint8_t"},
+		{Name: "timestamp", Type: "float"},
+		{Name: "timestamp_accuracy", Type: "uint8_t"},
+	}},
+	{ID: 12902, Name: "OPEN_DRONE_ID_AUTHENTICATION", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "id_or_mac", Type: "uint8_t", ArrayLen: 20},
+		{Name: "authentication_type", Type: "uint8_t"},
+		{Name: "data_page", Type: "uint8_t"},
+		{Name: "last_page_index", Type: "uint8_t"},
+		{Name: "length", Type: "uint8_t"},
+		{Name: "timestamp", Type: "uint32_t"},
+		{Name: "authentication_data", Type: "uint8_t", ArrayLen: 23},
+	}},
+	{ID: 12903, Name: "OPEN_DRONE_ID_SELF_ID", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "id_or_mac", Type: "uint8_t", ArrayLen: 20},
+		{Name: "description_type", Type: "uint8_t"},
+		{Name: "description", Type: "char", ArrayLen: 23},
+	}},
+	{ID: 12904, Name: "OPEN_DRONE_ID_SYSTEM", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "id_or_mac", Type: "uint8_t", ArrayLen: 20},
+		{Name: "operator_location_type", Type: "uint8_t"},
+		{Name: "classification_type", Type: "uint8_t"},
+		{Name: "operator_latitude", Type: "int32_t"},
+		{Name: "operator_longitude", Type: "int32_t"},
+		{Name: "area_count", Type: "uint16_t"},
+		{Name: "area_radius", Type: "uint16_t"},
+		{Name: "area_ceiling", Type: "float"},
+		{Name: "area_floor", Type: "float"},
+		{Name: "category_eu", Type: "uint8_t"},
+		{Name: "class_eu", Type: "uint8_t"},
+		{Name: "operator_altitude_geo", Type: "float"},
+		{Name: "timestamp", Type: "uint32_t"},
+	}},
+	{ID: 12905, Name: "OPEN_DRONE_ID_OPERATOR_ID", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "id_or_mac", Type: "uint8_t", ArrayLen: 20},
+		{Name: "operator_id_type", Type: "uint8_t"},
+		{Name: "operator_id", Type: "char", ArrayLen: 20},
+	}},
+	{ID: 12915, Name: "OPEN_DRONE_ID_MESSAGE_PACK", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "id_or_mac", Type: "uint8_t", ArrayLen: 20},
+		{Name: "single_message_size", Type: "uint8_t"},
+		{Name: "msg_pack_size", Type: "uint8_t"},
+		{Name: "messages", Type: "uint8_t", ArrayLen: 225},
+	}},
+	{ID: 12918, Name: "OPEN_DRONE_ID_ARM_STATUS", Fields: []mavlinkField{
+		{Name: "status", Type: "uint8_t"},
+		{Name: "error", Type: "char", ArrayLen: 50},
+	}},
+	{ID: 12919, Name: "OPEN_DRONE_ID_SYSTEM_UPDATE", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "operator_latitude", Type: "int32_t"},
+		{Name: "operator_longitude", Type: "int32_t"},
+		{Name: "operator_altitude_geo", Type: "float"},
+		{Name: "timestamp", Type: "uint32_t"},
+	}},
+	{ID: 12920, Name: "HYGROMETER_SENSOR", Fields: []mavlinkField{
+		{Name: "id", Type: "uint8_t"},
+		{Name: "temperature", Type: "int16_t"},
+		{Name: "humidity", Type: "uint16_t"},
+	}},
+	{ID: 42000, Name: "ICAROUS_HEARTBEAT", Fields: []mavlinkField{
+		{Name: "status", Type: "uint8_t"},
+	}},
+	{ID: 42001, Name: "ICAROUS_KINEMATIC_BANDS", Fields: []mavlinkField{
+		{Name: "numBands", Type: "int8_t"},
+		{Name: "type1", Type: "uint8_t"},
+		{Name: "min1", Type: "float"},
+		{Name: "max1", Type: "float"},
+		{Name: "type2", Type: "uint8_t"},
+		{Name: "min2", Type: "float"},
+		{Name: "max2", Type: "float"},
+		{Name: "type3", Type: "uint8_t"},
+		{Name: "min3", Type: "float"},
+		{Name: "max3", Type: "float"},
+		{Name: "type4", Type: "uint8_t"},
+		{Name: "min4", Type: "float"},
+		{Name: "max4", Type: "float"},
+		{Name: "type5", Type: "uint8_t"},
+		{Name: "min5", Type: "float"},
+		{Name: "max5", Type: "float"},
+	}},
+	{ID: 50001, Name: "CUBEPILOT_RAW_RC", Fields: []mavlinkField{
+		{Name: "rc_raw", Type: "uint8_t", ArrayLen: 32},
+	}},
+	{ID: 50002, Name: "HERELINK_VIDEO_STREAM_INFORMATION", Fields: []mavlinkField{
+		{Name: "camera_id", Type: "uint8_t"},
+		{Name: "status", Type: "uint8_t"},
+		{Name: "framerate", Type: "float"},
+		{Name: "resolution_h", Type: "uint16_t"},
+		{Name: "resolution_v", Type: "uint16_t"},
+		{Name: "bitrate", Type: "uint32_t"},
+		{Name: "rotation", Type: "uint16_t"},
+		{Name: "uri", Type: "char", ArrayLen: 230},
+	}},
+	{ID: 50003, Name: "HERELINK_TELEM", Fields: []mavlinkField{
+		{Name: "rssi", Type: "uint8_t"},
+		{Name: "snr", Type: "int16_t"},
+		{Name: "rf_freq", Type: "uint32_t"},
+		{Name: "link_bw", Type: "uint32_t"},
+		{Name: "link_rate", Type: "uint32_t"},
+		{Name: "cpu_temp", Type: "int16_t"},
+		{Name: "board_temp", Type: "int16_t"},
+	}},
+	{ID: 50004, Name: "CUBEPILOT_FIRMWARE_UPDATE_START", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "size", Type: "uint32_t"},
+		{Name: "crc", Type: "uint32_t"},
+	}},
+	{ID: 50005, Name: "CUBEPILOT_FIRMWARE_UPDATE_RESP", Fields: []mavlinkField{
+		{Name: "target_system", Type: "uint8_t"},
+		{Name: "target_component", Type: "uint8_t"},
+		{Name: "offset", Type: "uint32_t"},
+	}},
+	{ID: 52000, Name: "AIRLINK_AUTH", Fields: []mavlinkField{
+		{Name: "login", Type: "char", ArrayLen: 50},
+		{Name: "password", Type: "char", ArrayLen: 50},
+	}},
+	{ID: 52001, Name: "AIRLINK_AUTH_RESPONSE", Fields: []mavlinkField{
+		{Name: "resp_type", Type: "uint8_t"},
+	}},
+}
@@ -0,0 +1,56 @@
+package dataflash
+
+import "testing"
+
+func TestMavlinkCRCExtra(t *testing.T) {
+	// Published CRC_EXTRA values of a sample of the generated dialect,
+	// covering common, ardupilotmega and IDs above 255
+	expected := map[uint32]struct {
+		name     string
+		crcExtra byte
+	}{
+		0:     {"HEARTBEAT", 50},
+		1:     {"SYS_STATUS", 124},
+		2:     {"SYSTEM_TIME", 137},
+		22:    {"PARAM_VALUE", 220},
+		24:    {"GPS_RAW_INT", 24},
+		30:    {"ATTITUDE", 39},
+		33:    {"GLOBAL_POSITION_INT", 104},
+		36:    {"SERVO_OUTPUT_RAW", 222},
+		44:    {"MISSION_COUNT", 221},
+		65:    {"RC_CHANNELS", 118},
+		73:    {"MISSION_ITEM_INT", 38},
+		74:    {"VFR_HUD", 20},
+		76:    {"COMMAND_LONG", 152},
+		77:    {"COMMAND_ACK", 143},
+		111:   {"TIMESYNC", 34},
+		147:   {"BATTERY_STATUS", 154},
+		148:   {"AUTOPILOT_VERSION", 178},
+		152:   {"MEMINFO", 208},
+		178:   {"AHRS2", 47},
+		193:   {"EKF_STATUS_REPORT", 71},
+		253:   {"STATUSTEXT", 83},
+		300:   {"PROTOCOL_VERSION", 217},
+		11030: {"ESC_TELEMETRY_1_TO_4", 144},
+	}
+	for id, want := range expected {
+		m, ok := mavlinkMessages[id]
+		if !ok || m.Name != want.name || m.crcExtra != want.crcExtra {
+			t.Errorf("message %d: expected %s with CRC_EXTRA %d, got %+v", id, want.name, want.crcExtra, m)
+		}
+	}
+
+	lengths := map[string][2]int{"HEARTBEAT": {9, 9}, "SYS_STATUS": {31, 43}, "GPS_RAW_INT": {30, 52}, "STATUSTEXT": {51, 54}}
+	for _, m := range mavlinkMessages {
+		if want, ok := lengths[m.Name]; ok && (m.minLen != want[0] || m.maxLen != want[1]) {
+			t.Errorf("%s: expected payload lengths %v, got %d and %d", m.Name, want, m.minLen, m.maxLen)
+		}
+	}
+}
+
+func TestCRCX25(t *testing.T) {
+	// Check value of CRC-16/MCRF4XX
+	if crc := crcX25(crcX25Init, []byte("123456789")); crc != 0x6f91 {
+		t.Errorf("expected 0x6f91, got %#04x", crc)
+	}
+}
@@ -0,0 +1,501 @@
+package dataflash
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// clockSamples is the number of GPS messages used to align the clock of a
+// merged log.
+const clockSamples = 15
+
+// mergedSchemaMessages are the layouts of the FMTU, UNIT and MULT messages
+// of a merged log, as ArduPilot defines them. The merged log defines each
+// one that an input defines, whatever layout the input used.
+var mergedSchemaMessages = map[string]*Schema{
+	"FMTU": {Name: "FMTU", Length: 44, Format: "QBNN", Columns: "TimeUS,FmtType,UnitIds,MultIds"},
+	"UNIT": {Name: "UNIT", Length: 76, Format: "QbZ", Columns: "TimeUS,Id,Label"},
+	"MULT": {Name: "MULT", Length: 20, Format: "Qbd", Columns: "TimeUS,Id,Mult"},
+}
+
+// Merger combines several logs recorded at the same time, such as the logs
+// of an autopilot and of a companion computer, into one time-ordered log.
+//
+// Each distinct message layout gets one type ID in the merged log. Layouts
+// are kept on their original ID where possible; a layout whose ID is already
+// taken by a different one, such as a companion message using the ID of an
+// autopilot message, is moved to a free ID. Layouts that are the same in
+// several logs share an ID. A layout whose name is already taken by a
+// different one is renamed, with the last character of the name replaced by
+// a digit from 2 (IMU becomes IMU2, BARO BAR2), so that name-based lookups
+// on the merged log find each layout. FMT, FMTU, UNIT and MULT are never
+// renamed: the merged log defines them once, in the layouts of
+// mergedSchemaMessages, and units from FMTU messages an input could not apply
+// are left out.
+//
+// Inputs must agree on the meaning of the unit and multiplier identifiers
+// they define, as GetScaled resolves them the same way for every log.
+//
+// Clocks are aligned through GPS time: the offset of each log is the median
+// of GPS time minus TimeUS over its first GWk and GMS messages, and TimeUS
+// is shifted so that all logs count from the boot of the earliest one. Logs
+// without GPS time keep their own clock; SetOffset overrides the offset.
+type Merger struct {
+	inputs  []*Parser
+	offsets []int64             // Microseconds added to the TimeUS of each input
+	types   map[*Schema]*Schema // Merged schema of each input schema
+	schemas []*Schema           // Merged schemas in type order
+	units   map[rune]string
+	mults   map[rune]float64
+}
+
+// MergedMessage is a message of the merged log.
+type MergedMessage struct {
+	*Message     // Type, Name, TimeUS and Fields["TimeUS"] are those of the merged log
+	Input    int // Index of the input the message was read from
+}
+
+// NewMerger prepares to merge inputs. It reads the schemas of every input
+// and scans each for GPS time, rewinding them when done. Inputs may be
+// segment views from OpenSegment, and should each cover a single boot.
+// Returns ErrNotSeekable for stream parsers; parsers created with
+// WithPrescan(false) are rejected as their schemas are not known up front.
+func NewMerger(inputs ...*Parser) (*Merger, error) {
+	return NewMergerContext(context.Background(), inputs...)
+}
+
+// NewMergerContext is like NewMerger but stops with ctx.Err() once ctx is
+// done.
+func NewMergerContext(ctx context.Context, inputs ...*Parser) (*Merger, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("failed to merge: no inputs")
+	}
+	m := &Merger{
+		inputs:  inputs,
+		offsets: make([]int64, len(inputs)),
+		types:   make(map[*Schema]*Schema),
+		units:   make(map[rune]string),
+		mults:   make(map[rune]float64),
+	}
+	for i, p := range inputs {
+		if err := p.Rewind(); err != nil {
+			return nil, err
+		}
+		if p.lazy {
+			return nil, fmt.Errorf("failed to merge input %d: schemas are not pre-scanned", i)
+		}
+		for id, unit := range p.Units() {
+			if prev, ok := m.units[id]; ok && prev != unit {
+				return nil, fmt.Errorf("failed to merge input %d: unit %q is %q, but %q in an earlier input", i, id, unit, prev)
+			}
+			m.units[id] = unit
+		}
+		for id, mult := range p.Multipliers() {
+			if prev, ok := m.mults[id]; ok && prev != mult {
+				return nil, fmt.Errorf("failed to merge input %d: multiplier %q is %v, but %v in an earlier input", i, id, mult, prev)
+			}
+			m.mults[id] = mult
+		}
+	}
+	if err := m.mapTypes(); err != nil {
+		return nil, err
+	}
+
+	clocks := make([]int64, len(inputs))
+	aligned := make([]bool, len(inputs))
+	for i, p := range inputs {
+		var err error
+		clocks[i], aligned[i], err = p.gpsClock(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GPS time of input %d: %w", i, err)
+		}
+	}
+	earliest := int64(0)
+	first := true
+	for i, clock := range clocks {
+		if aligned[i] && (first || clock < earliest) {
+			earliest, first = clock, false
+		}
+	}
+	for i, clock := range clocks {
+		if aligned[i] {
+			m.offsets[i] = clock - earliest
+		}
+	}
+	return m, nil
+}
+
+// mapTypes assigns a type ID in the merged log to every schema of the
+// inputs.
+func (m *Merger) mapTypes() error {
+	type layout struct {
+		Name, Format, Columns, Units, Mults string
+		Length                              uint8
+	}
+	byLayout := make(map[layout]*Schema)
+	used := map[uint8]bool{FMTType: true}
+	names := map[string]bool{fmtSchema.Name: true}
+	for name := range mergedSchemaMessages {
+		names[name] = true
+	}
+
+	free := 255
+	// nextFree returns the highest type ID not in use
+	nextFree := func() (uint8, error) {
+		for free >= 0 && used[uint8(free)] {
+			free--
+		}
+		if free < 0 {
+			return 0, fmt.Errorf("failed to merge: more than 255 message types")
+		}
+		return uint8(free), nil
+	}
+
+	// The schema messages keep the ID of the first input defining them,
+	// ahead of any other layout
+	meta := make(map[string]*Schema)
+	for _, p := range m.inputs {
+		for typ := range 256 {
+			for _, s := range p.SchemaVersions(uint8(typ)) {
+				template, ok := mergedSchemaMessages[s.Name]
+				if !ok || meta[s.Name] != nil {
+					continue
+				}
+				merged := template.clone()
+				merged.Type = s.Type
+				if used[s.Type] {
+					id, err := nextFree()
+					if err != nil {
+						return err
+					}
+					merged.Type = id
+				}
+				used[merged.Type] = true
+				meta[s.Name] = merged
+			}
+		}
+	}
+
+	// add gives s, a copy of an input schema, a free name and records it
+	add := func(key layout, s *Schema) error {
+		if names[s.Name] {
+			base := s.Name[:min(len(s.Name), 3)]
+			for d := '2'; names[s.Name] && d <= '9'; d++ {
+				s.Name = base + string(d)
+			}
+			if names[s.Name] {
+				return fmt.Errorf("failed to merge: too many layouts named %s", key.Name)
+			}
+		}
+		names[s.Name] = true
+		byLayout[key] = s
+		used[s.Type] = true
+		return nil
+	}
+
+	// Schemas keeping their ID come first, so that a later input cannot
+	// take the ID of an earlier one's schema
+	var moved []*Schema
+	for _, p := range m.inputs {
+		for typ := range 256 {
+			for _, s := range p.SchemaVersions(uint8(typ)) {
+				key := layout{s.Name, s.Format, s.Columns, s.Units, s.Mults, s.Length}
+				if merged, ok := byLayout[key]; ok {
+					m.types[s] = merged
+					continue
+				}
+				if s.Type == FMTType || s.Name == fmtSchema.Name {
+					m.types[s] = fmtSchema
+					continue
+				}
+				if merged, ok := meta[s.Name]; ok {
+					m.types[s] = merged
+					continue
+				}
+				if used[s.Type] {
+					moved = append(moved, s)
+					continue
+				}
+				merged := mergedCopy(s)
+				if err := add(key, merged); err != nil {
+					return err
+				}
+				m.types[s] = merged
+			}
+		}
+	}
+
+	for _, s := range moved {
+		key := layout{s.Name, s.Format, s.Columns, s.Units, s.Mults, s.Length}
+		if merged, ok := byLayout[key]; ok {
+			m.types[s] = merged
+			continue
+		}
+		id, err := nextFree()
+		if err != nil {
+			return err
+		}
+		merged := mergedCopy(s)
+		merged.Type = id
+		if err := add(key, merged); err != nil {
+			return err
+		}
+		m.types[s] = merged
+	}
+
+	m.schemas = slices.Collect(maps.Values(byLayout))
+	m.schemas = slices.AppendSeq(m.schemas, maps.Values(meta))
+	slices.SortFunc(m.schemas, func(a, b *Schema) int {
+		return int(a.Type) - int(b.Type)
+	})
+	return nil
+}
+
+// mergedCopy returns a copy of the input schema s for the merged log. Units
+// and multipliers that don't match the fields of s, set by a malformed FMTU
+// message the input tolerated, are left out.
+func mergedCopy(s *Schema) *Schema {
+	merged := s.clone()
+	if len(s.Units) != len(s.Fields()) || len(s.Mults) != len(s.Fields()) {
+		merged.Units, merged.Mults = "", ""
+	}
+	return merged
+}
+
+// gpsClock returns the GPS time of the boot of p in microseconds, from the
+// median of GPS time minus TimeUS over the first clockSamples messages with
+// GWk and GMS fields, and rewinds p. It reports false if p has no GPS time.
+func (p *Parser) gpsClock(ctx context.Context) (int64, bool, error) {
+	var samples []int64
+	for len(samples) < clockSamples {
+		if err := checkContext(ctx, p.lineNo); err != nil {
+			return 0, false, err
+		}
+
+		schema, body, err := p.skipMessage()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		p.reportProgress(StageMerge, p.lineNo, false)
+
+		week, ms := schema.ColumnIndex("GWk"), schema.ColumnIndex("GMS")
+		if week < 0 || ms < 0 || schema.timeUSOffset() < 0 {
+			continue
+		}
+		r := FieldReader{schema: schema, body: body}
+		if r.Uint64(week) == 0 {
+			continue // No fix yet
+		}
+		gpsUS := (int64(r.Uint64(week))*gpsWeekMS + int64(r.Uint64(ms))) * 1000
+		samples = append(samples, gpsUS-r.Int64(schema.ColumnIndex("TimeUS")))
+	}
+	p.reportProgress(StageMerge, p.lineNo, true)
+
+	if err := p.Rewind(); err != nil {
+		return 0, false, err
+	}
+	if len(samples) == 0 {
+		return 0, false, nil
+	}
+	slices.Sort(samples)
+	return samples[len(samples)/2], true, nil
+}
+
+// Offsets returns the number of microseconds added to the TimeUS of each
+// input, 0 for inputs without GPS time.
+func (m *Merger) Offsets() []int64 {
+	return slices.Clone(m.offsets)
+}
+
+// SetOffset sets the number of microseconds added to the TimeUS of input i,
+// for inputs whose clock cannot be aligned through GPS time. Offsets must
+// not make TimeUS negative.
+func (m *Merger) SetOffset(i int, us int64) {
+	m.offsets[i] = us
+}
+
+// Schemas returns the schemas of the merged log in type order, including
+// those of FMTU, UNIT and MULT messages. FMT keeps type 128.
+func (m *Merger) Schemas() []*Schema {
+	return slices.Clone(m.schemas)
+}
+
+// Units returns the unit identifiers of all inputs.
+func (m *Merger) Units() map[rune]string {
+	return m.units
+}
+
+// Multipliers returns the multiplier identifiers of all inputs.
+func (m *Merger) Multipliers() map[rune]float64 {
+	return m.mults
+}
+
+// All rewinds the inputs and returns an iterator over their messages in
+// TimeUS order, subject to each input's filter. Messages without TimeUS
+// follow the message before them in their input, and messages with equal
+// TimeUS come in input order. FMT, FMTU, UNIT and MULT messages are left
+// out; Schemas, Units and Multipliers describe the merged log instead.
+// Errors end the iteration as in Parser.All.
+func (m *Merger) All() iter.Seq2[*MergedMessage, error] {
+	return m.AllContext(context.Background())
+}
+
+// AllContext is like All but stops with ctx.Err() once ctx is done.
+func (m *Merger) AllContext(ctx context.Context) iter.Seq2[*MergedMessage, error] {
+	return func(yield func(*MergedMessage, error) bool) {
+		type head struct {
+			msg *MergedMessage
+			key int64 // TimeUS, or that of the message before
+		}
+		heads := make([]*head, len(m.inputs))
+		nexts := make([]func() (*Message, error, bool), len(m.inputs))
+		for i, p := range m.inputs {
+			if err := p.Rewind(); err != nil {
+				yield(nil, err)
+				return
+			}
+			next, stop := iter.Pull2(p.AllContext(ctx))
+			defer stop()
+			nexts[i] = next
+			heads[i] = &head{}
+		}
+
+		// advance reads the next message of input i into heads[i], or sets
+		// it to nil at the end of the input
+		advance := func(i int) error {
+			for {
+				msg, err, ok := nexts[i]()
+				if !ok {
+					heads[i] = nil
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				if isSchemaMessage(msg.Name) {
+					continue
+				}
+				if err := m.convert(i, msg); err != nil {
+					return err
+				}
+				if _, ok := msg.Fields["TimeUS"]; ok {
+					heads[i].key = msg.TimeUS
+				}
+				heads[i].msg = &MergedMessage{Message: msg, Input: i}
+				return nil
+			}
+		}
+
+		for i := range heads {
+			if err := advance(i); err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+		for {
+			next := -1
+			for i, h := range heads {
+				if h != nil && (next < 0 || h.key < heads[next].key) {
+					next = i
+				}
+			}
+			if next < 0 {
+				return
+			}
+			if !yield(heads[next].msg, nil) {
+				return
+			}
+			if err := advance(next); err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
+
+// convert moves msg, read from input i, to the type IDs and clock of the
+// merged log.
+func (m *Merger) convert(i int, msg *Message) error {
+	schema, ok := m.types[msg.schema]
+	if !ok {
+		return fmt.Errorf("failed to merge %s at offset %d of input %d: schema not known up front",
+			msg.Name, msg.Offset, i)
+	}
+	msg.Type = schema.Type
+	msg.Name = schema.Name
+	msg.schema = schema
+
+	offset := m.offsets[i]
+	switch v := msg.Fields["TimeUS"].(type) {
+	case uint64:
+		if offset < 0 && uint64(-offset) > v {
+			return fmt.Errorf("failed to merge %s at offset %d of input %d: TimeUS %d would be negative",
+				msg.Name, msg.Offset, i, int64(v)+offset)
+		}
+		msg.Fields["TimeUS"] = uint64(int64(v) + offset)
+		msg.TimeUS = int64(v) + offset
+	case int64:
+		msg.Fields["TimeUS"] = v + offset
+		msg.TimeUS = v + offset
+	}
+	return nil
+}
+
+// Write writes the merged log to dst as a .bin file: FMT messages for
+// Schemas, UNIT and MULT messages for Units and Multipliers, then the
+// messages of All.
+func (m *Merger) Write(dst io.Writer) error {
+	return m.WriteContext(context.Background(), dst)
+}
+
+// WriteContext is like Write but stops with ctx.Err() once ctx is done.
+func (m *Merger) WriteContext(ctx context.Context, dst io.Writer) error {
+	w := NewWriter(dst)
+
+	// FMTU must be defined before schemas with units
+	for _, name := range []string{"FMTU", "UNIT", "MULT"} {
+		for _, s := range m.schemas {
+			if s.Name != name {
+				continue
+			}
+			if err := w.WriteSchema(s); err != nil {
+				return err
+			}
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(m.units)) {
+		if err := w.WriteUnit(id, m.units[id]); err != nil {
+			return err
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(m.mults)) {
+		if err := w.WriteMult(id, m.mults[id]); err != nil {
+			return err
+		}
+	}
+	for _, s := range m.schemas {
+		if isSchemaMessage(s.Name) {
+			continue
+		}
+		if err := w.WriteSchema(s); err != nil {
+			return err
+		}
+	}
+
+	for msg, err := range m.AllContext(ctx) {
+		if err != nil {
+			return err
+		}
+		if err := w.WriteMessage(msg.Message); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,285 @@
+package dataflash
+
+import (
+	"bytes"
+	"maps"
+	"testing"
+)
+
+var testCMPSchema = &Schema{Type: testIMUType, Length: 15, Name: "CMP", Format: "Qf", Columns: "TimeUS,Load"}
+
+// autopilotLog returns a log with 30 IMU messages 1ms apart and a GPT
+// message every 10ms, booted at 100 s into GPS week 2300.
+func autopilotLog() []byte {
+	var l testLog
+	l.writePreamble()
+	l.writeFMT(testGPSTimeSchema)
+	for i := 1; i <= 30; i++ {
+		timeUS := uint64(1000 * i)
+		l.writeIMU(timeUS)
+		if i%10 == 0 {
+			l.writeGPT(timeUS, 100_000)
+		}
+	}
+	return l.Bytes()
+}
+
+// companionLog returns a log booted 2 s after autopilotLog, with a CMP
+// message using the type ID of IMU, IMU messages under another type ID and
+// GPT messages.
+func companionLog() []byte {
+	imu := testIMUSchema.clone()
+	imu.Type = 140
+
+	var l testLog
+	l.writeFMT(fmtSchema)
+	l.writeFMT(testFMTUSchema)
+	l.writeFMT(testCMPSchema)
+	l.writeFMT(imu)
+	l.writeFMT(testGPSTimeSchema)
+	l.writeFMTU(0, imu.Type, "s#EEE", "F-000")
+	for i := 1; i <= 30; i++ {
+		timeUS := uint64(1000 * i)
+		l.writeMessage(testCMPSchema.Type, timeUS, float32(i))
+		if i%5 == 0 {
+			l.writeMessage(imu.Type, timeUS, uint8(1), float32(0.1), float32(0.2), float32(0.3))
+		}
+		if i%10 == 0 {
+			l.writeGPT(timeUS, 102_000)
+		}
+	}
+	return l.Bytes()
+}
+
+// writeGPT appends a GPT message for a log booted bootMS into GPS week 2300.
+func (l *testLog) writeGPT(timeUS uint64, bootMS uint32) {
+	l.writeMessage(testGPSTimeSchema.Type, timeUS, uint16(2300), bootMS+uint32(timeUS/1000),
+		int32(50.45e7), int32(30.52e7))
+}
+
+// newTestMerger returns a Merger of autopilotLog and companionLog.
+func newTestMerger(t *testing.T) *Merger {
+	t.Helper()
+	var inputs []*Parser
+	for _, data := range [][]byte{autopilotLog(), companionLog()} {
+		parser, err := NewParserFromBytes(data)
+		if err != nil {
+			t.Fatalf("failed to create parser: %v", err)
+		}
+		inputs = append(inputs, parser)
+	}
+	m, err := NewMerger(inputs...)
+	if err != nil {
+		t.Fatalf("failed to create merger: %v", err)
+	}
+	return m
+}
+
+func TestMerge(t *testing.T) {
+	m := newTestMerger(t)
+	if offsets := m.Offsets(); offsets[0] != 0 || offsets[1] != 2_000_000 {
+		t.Errorf("expected offsets [0 2000000], got %v", offsets)
+	}
+
+	types := make(map[string]uint8)
+	for _, s := range m.Schemas() {
+		types[s.Name] = s.Type
+	}
+	expectedTypes := map[string]uint8{"FMTU": testFMTUType, "IMU": testIMUType, "GPS": testGPSType, "GPT": 154, "CMP": 255}
+	if !maps.Equal(types, expectedTypes) {
+		t.Errorf("expected types %v, got %v", expectedTypes, types)
+	}
+
+	counts := make(map[[2]any]int)
+	last := int64(0)
+	for msg, err := range m.All() {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		counts[[2]any{msg.Input, msg.Name}]++
+		if msg.Type != types[msg.Name] {
+			t.Errorf("expected %s to have type %d, got %d", msg.Name, types[msg.Name], msg.Type)
+		}
+		if msg.TimeUS < last || msg.Fields["TimeUS"] != uint64(msg.TimeUS) {
+			t.Errorf("message at %d out of order after %d", msg.TimeUS, last)
+		}
+		last = msg.TimeUS
+		if msg.Name == "GPT" {
+			if boot := int64(msg.MustFloat64("GMS"))*1000 - msg.TimeUS; boot != 100_000_000 {
+				t.Errorf("expected GPS messages to agree on the boot time, got %d", boot)
+			}
+		}
+	}
+	expected := map[[2]any]int{
+		{0, "IMU"}: 30, {0, "GPT"}: 3,
+		{1, "CMP"}: 30, {1, "IMU"}: 6, {1, "GPT"}: 3,
+	}
+	if !maps.Equal(counts, expected) {
+		t.Errorf("expected %v, got %v", expected, counts)
+	}
+	if last != 2_030_000 {
+		t.Errorf("expected the merged log to end at 2030000, got %d", last)
+	}
+}
+
+func TestMergeWrite(t *testing.T) {
+	m := newTestMerger(t)
+	var out bytes.Buffer
+	if err := m.Write(&out); err != nil {
+		t.Fatalf("failed to write merged log: %v", err)
+	}
+
+	parser, err := NewParserFromBytes(out.Bytes())
+	if err != nil {
+		t.Fatalf("failed to parse merged log: %v", err)
+	}
+	counts := countMessages(t, parser)
+	expected := map[string]int{"FMT": 6, "FMTU": 2, "IMU": 36, "GPT": 6, "CMP": 30}
+	if !maps.Equal(counts, expected) {
+		t.Errorf("expected %v, got %v", expected, counts)
+	}
+	if d := parser.Diagnostics(); len(d.Resyncs) > 0 || len(d.UnknownTypes) > 0 {
+		t.Errorf("merged log is corrupt: %+v", d)
+	}
+
+	parser.Rewind()
+	parser.SetFilter("CMP")
+	msg, err := parser.ReadMessage()
+	if err != nil || msg.Type != 255 || msg.TimeUS != 2_001_000 {
+		t.Errorf("expected CMP as type 255 at 2001000, got %+v, %v", msg, err)
+	}
+	parser.SetFilter("IMU")
+	msg, err = parser.ReadMessage()
+	if err != nil {
+		t.Fatalf("failed to read IMU: %v", err)
+	}
+	if _, unit, _ := msg.GetScaled("TimeUS"); unit != "s" {
+		t.Errorf("expected units to be kept, got %q", unit)
+	}
+}
+
+func TestMergeOffsets(t *testing.T) {
+	m := newTestMerger(t)
+	m.SetOffset(1, -1_000_000)
+	for msg, err := range m.All() {
+		if err == nil {
+			t.Fatalf("expected an error for a negative TimeUS, got %s at %d", msg.Name, msg.TimeUS)
+		}
+		break
+	}
+
+	stream := NewStreamParser(bytes.NewReader(autopilotLog()))
+	if _, err := NewMerger(stream); err != ErrNotSeekable {
+		t.Errorf("expected ErrNotSeekable, got %v", err)
+	}
+	if _, err := NewMerger(); err == nil {
+		t.Error("expected an error without inputs")
+	}
+}
+
+func TestMergeConflicts(t *testing.T) {
+	// The companion logs a different IMU layout under the autopilot's ID
+	var l testLog
+	l.writeFMT(fmtSchema)
+	l.writeFMT(testIMU2Schema)
+	for i := 1; i <= 5; i++ {
+		l.writeMessage(testIMUType, uint64(1000*i), uint8(0), float32(0.1), float32(0.2))
+	}
+	var inputs []*Parser
+	for _, data := range [][]byte{autopilotLog(), l.Bytes()} {
+		parser, err := NewParserFromBytes(data)
+		if err != nil {
+			t.Fatalf("failed to create parser: %v", err)
+		}
+		inputs = append(inputs, parser)
+	}
+	m, err := NewMerger(inputs...)
+	if err != nil {
+		t.Fatalf("failed to create merger: %v", err)
+	}
+
+	types := make(map[string]uint8)
+	for _, s := range m.Schemas() {
+		types[s.Name] = s.Type
+	}
+	if types["IMU"] != testIMUType || types["IMU2"] != 255 {
+		t.Errorf("expected IMU as type %d and IMU2 as type 255, got %v", testIMUType, types)
+	}
+	var out bytes.Buffer
+	if err := m.Write(&out); err != nil {
+		t.Fatalf("failed to write merged log: %v", err)
+	}
+	parser, err := NewParserFromBytes(out.Bytes())
+	if err != nil {
+		t.Fatalf("failed to parse merged log: %v", err)
+	}
+	if counts := countMessages(t, parser); counts["IMU"] != 30 || counts["IMU2"] != 5 {
+		t.Errorf("expected 30 IMU and 5 IMU2 messages, got %v", counts)
+	}
+
+	// The companion defines FMTU in another layout and has a FMTU message
+	// that doesn't match its schema
+	fmtu := &Schema{Type: testFMTUType, Length: 45, Name: "FMTU", Format: "QBNNB",
+		Columns: "TimeUS,FmtType,UnitIds,MultIds,Extra"}
+	l.Reset()
+	l.writeFMT(fmtSchema)
+	l.writeFMT(fmtu)
+	l.writeFMT(testCMPSchema)
+	l.writeMessage(fmtu.Type, uint64(0), testCMPSchema.Type)
+	l.writeString("s", 16)
+	l.writeString("F", 16)
+	l.WriteByte(0)
+	for i := 1; i <= 5; i++ {
+		l.writeMessage(testCMPSchema.Type, uint64(1000*i), float32(0.5))
+	}
+	inputs[1], err = NewParserFromBytes(l.Bytes())
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if err := inputs[0].Rewind(); err != nil {
+		t.Fatalf("failed to rewind: %v", err)
+	}
+	m, err = NewMerger(inputs...)
+	if err != nil {
+		t.Fatalf("failed to create merger: %v", err)
+	}
+	names := make(map[string]int)
+	for _, s := range m.Schemas() {
+		names[s.Name]++
+	}
+	if names["FMTU"] != 1 || names["FMT2"] != 0 {
+		t.Errorf("expected one FMTU schema, got %v", names)
+	}
+	out.Reset()
+	if err := m.Write(&out); err != nil {
+		t.Fatalf("failed to write merged log: %v", err)
+	}
+	parser, err = NewParserFromBytes(out.Bytes(), WithParseMode(Strict))
+	if err != nil {
+		t.Fatalf("failed to parse merged log: %v", err)
+	}
+	if counts := countMessages(t, parser); counts["IMU"] != 30 || counts["CMP"] != 5 || counts["FMTU"] != 2 {
+		t.Errorf("expected 30 IMU, 5 CMP and 2 FMTU messages, got %v", counts)
+	}
+	for _, s := range parser.GetSchemas() {
+		if s.Name == "IMU" && s.Units != "s#EEE" || s.Name == "CMP" && s.Units != "" {
+			t.Errorf("unexpected units %q of %s", s.Units, s.Name)
+		}
+	}
+
+	// Logs disagreeing on a unit identifier can't share GetScaled
+	var logs [2]testLog
+	for i, label := range []string{"m", "ft"} {
+		logs[i].writeFMT(fmtSchema)
+		logs[i].writeFMT(testUnitSchema)
+		logs[i].writeMessage(testUnitSchema.Type, uint64(0), int8('m'))
+		logs[i].writeString(label, 64)
+		inputs[i], err = NewParserFromBytes(logs[i].Bytes())
+		if err != nil {
+			t.Fatalf("failed to create parser: %v", err)
+		}
+	}
+	if _, err := NewMerger(inputs...); err == nil {
+		t.Error("expected an error for conflicting units")
+	}
+}
@@ -20,35 +20,17 @@ func (m *Message) GetScaled(field string) (any, string, error) {
 		return nil, "", fmt.Errorf("field %q not found in message", field)
 	}
 
-	// Find field index in schema
-	fieldIndex := -1
-	columns := parseColumns(m.schema.Columns)
-	for i, col := range columns {
-		if col == field {
-			fieldIndex = i
-			break
-		}
-	}
-
+	// Find field in schema
+	fieldIndex := m.schema.ColumnIndex(field)
 	if fieldIndex == -1 {
 		return nil, "", fmt.Errorf("field %q not found in schema", field)
 	}
+	def := m.schema.Fields()[fieldIndex]
 
-	// Get format character to determine if scaling was already applied during decoding
-	formatChar := '-'
-	if fieldIndex < len(m.schema.Format) {
-		formatChar = rune(m.schema.Format[fieldIndex])
-	}
-
-	// Get unit and multiplier characters
-	unitChar := '-'
-	multChar := '-'
-	if fieldIndex < len(m.schema.Units) {
-		unitChar = rune(m.schema.Units[fieldIndex])
-	}
-	if fieldIndex < len(m.schema.Mults) {
-		multChar = rune(m.schema.Mults[fieldIndex])
-	}
+	// Format character determines if scaling was already applied during decoding
+	formatChar := def.Format
+	unitChar := def.Unit
+	multChar := def.Mult
 
 	var scaledValue any = value
 
@@ -82,29 +64,16 @@ func (m *Message) GetScaledFields() map[string]ScaledValue {
 		return result
 	}
 
-	columns := parseColumns(m.schema.Columns)
-	for i, col := range columns {
+	for _, def := range m.schema.Fields() {
 		// Get value
-		value, exists := m.Fields[col]
+		value, exists := m.Fields[def.Name]
 		if !exists {
 			continue
 		}
 
-		// Get format character
-		formatChar := '-'
-		if i < len(m.schema.Format) {
-			formatChar = rune(m.schema.Format[i])
-		}
-
-		// Get unit and multiplier characters
-		unitChar := '-'
-		multChar := '-'
-		if i < len(m.schema.Units) {
-			unitChar = rune(m.schema.Units[i])
-		}
-		if i < len(m.schema.Mults) {
-			multChar = rune(m.schema.Mults[i])
-		}
+		formatChar := def.Format
+		unitChar := def.Unit
+		multChar := def.Mult
 
 		var scaledValue any = value
 
@@ -121,7 +90,7 @@ func (m *Message) GetScaledFields() map[string]ScaledValue {
 		// Get unit name
 		unit := getUnitName(unitChar)
 
-		result[col] = ScaledValue{
+		result[def.Name] = ScaledValue{
 			Value: scaledValue,
 			Unit:  unit,
 		}
@@ -175,31 +144,3 @@ func formatHasScaling(formatChar rune) bool {
 		return false
 	}
 }
-
-// parseColumns splits a comma-separated column string into individual field names
-func parseColumns(columns string) []string {
-	if columns == "" {
-		return nil
-	}
-
-	var result []string
-	var current string
-	
-	for _, ch := range columns {
-		if ch == ',' {
-			if current != "" {
-				result = append(result, current)
-				current = ""
-			}
-		} else {
-			current += string(ch)
-		}
-	}
-	
-	// Add last field
-	if current != "" {
-		result = append(result, current)
-	}
-	
-	return result
-}
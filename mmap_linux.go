@@ -0,0 +1,69 @@
+//go:build linux
+
+package dataflash
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// NewMmapParser creates a parser for the given DataFlash log file that
+// memory-maps the file instead of reading it. Message bodies are decoded
+// directly from the mapped region, which suits repeated random access
+// through Rewind, SetFilter and GetSlice on large files.
+// Close unmaps the file; messages read before that remain valid, and
+// later reads return os.ErrClosed.
+func NewMmapParser(filename string, opts ...Option) (*Parser, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	// The mapping stays valid after the file is closed
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	// Mapping an empty file fails, and there is nothing to map anyway
+	var data []byte
+	if info.Size() > 0 {
+		data, err = syscall.Mmap(int(file.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map file: %w", err)
+		}
+	}
+
+	p, err := NewParserFromBytes(data, opts...)
+	if err != nil {
+		if data != nil {
+			syscall.Munmap(data)
+		}
+		return nil, err
+	}
+	if data != nil {
+		p.closer = &mapping{data: data, reader: p.r.(*memoryReader)}
+	}
+
+	if err := p.useIndexCache(context.Background(), filename); err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// mapping is a memory-mapped region that is unmapped on Close. The reader
+// over it is closed first so it no longer points into the region.
+type mapping struct {
+	data   []byte
+	reader *memoryReader
+}
+
+func (m *mapping) Close() error {
+	m.reader.close()
+	return syscall.Munmap(m.data)
+}
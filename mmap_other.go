@@ -0,0 +1,42 @@
+//go:build !linux
+
+package dataflash
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// NewMmapParser creates a parser for the given DataFlash log file.
+// Memory mapping is only implemented on Linux; on other platforms the whole
+// file is read into memory instead, which offers the same random access.
+// As with the mapped file, reads after Close return os.ErrClosed.
+func NewMmapParser(filename string, opts ...Option) (*Parser, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	p, err := NewParserFromBytes(data, opts...)
+	if err != nil {
+		return nil, err
+	}
+	p.closer = memoryCloser{p.r.(*memoryReader)}
+
+	if err := p.useIndexCache(context.Background(), filename); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// memoryCloser closes the reader over a file read into memory.
+type memoryCloser struct {
+	reader *memoryReader
+}
+
+func (m memoryCloser) Close() error {
+	m.reader.close()
+	return nil
+}
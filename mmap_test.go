@@ -0,0 +1,117 @@
+package dataflash
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTempLog writes data to a log file in a temporary directory.
+func writeTempLog(tb testing.TB, data []byte) string {
+	tb.Helper()
+	filename := filepath.Join(tb.TempDir(), "log.bin")
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		tb.Fatalf("failed to write log: %v", err)
+	}
+	return filename
+}
+
+func TestMmapParser(t *testing.T) {
+	filename := writeTempLog(t, corruptLog(500))
+
+	// Junk follows every message, so resyncs can't be confirmed by the
+	// header after the candidate
+	mapped, err := NewMmapParser(filename, WithResyncDepth(1))
+	if err != nil {
+		t.Fatalf("failed to create mmap parser: %v", err)
+	}
+	defer mapped.Close()
+	file, err := NewParserWithOptions(filename, WithResyncDepth(1))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	defer file.Close()
+
+	for _, p := range []*Parser{mapped, file} {
+		if err := p.SetFilter("IMU"); err != nil {
+			t.Fatalf("failed to set filter: %v", err)
+		}
+	}
+	for {
+		want, errWant := file.ReadMessage()
+		got, errGot := mapped.ReadMessage()
+		if errWant != errGot {
+			t.Fatalf("got error %v, want %v", errGot, errWant)
+		}
+		if errWant != nil {
+			break
+		}
+		if !reflect.DeepEqual(got.Fields, want.Fields) || got.LineNo != want.LineNo {
+			t.Fatalf("got %d %v, want %d %v", got.LineNo, got.Fields, want.LineNo, want.Fields)
+		}
+	}
+
+	// Slicing rewinds the mapped region
+	messages, err := mapped.GetSlice(100, 110, SliceByLineNo)
+	if err != nil {
+		t.Fatalf("error getting slice: %v", err)
+	}
+	if len(messages) != 10 || messages[0].LineNo != 100 {
+		t.Errorf("expected 10 messages from LineNo 100, got %d", len(messages))
+	}
+
+	// Messages stay valid after unmapping
+	if err := mapped.Close(); err != nil {
+		t.Fatalf("failed to close: %v", err)
+	}
+	if err := mapped.Close(); err != nil {
+		t.Errorf("second close failed: %v", err)
+	}
+	if messages[9].LineNo != 109 || messages[9].Fields["TimeUS"] == nil {
+		t.Errorf("unexpected message after close: %+v", messages[9])
+	}
+
+	// Reads after unmapping fail instead of faulting
+	if _, err := mapped.ReadMessage(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("expected os.ErrClosed reading after close, got %v", err)
+	}
+	if err := mapped.Rewind(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("expected os.ErrClosed rewinding after close, got %v", err)
+	}
+	if _, err := mapped.GetSlice(100, 110, SliceByLineNo); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("expected os.ErrClosed slicing after close, got %v", err)
+	}
+}
+
+func TestMmapParserEmptyFile(t *testing.T) {
+	parser, err := NewMmapParser(writeTempLog(t, nil))
+	if err != nil {
+		t.Fatalf("failed to create mmap parser: %v", err)
+	}
+	defer parser.Close()
+
+	if _, err := parser.ReadMessage(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func BenchmarkReadMessageMmap(b *testing.B) {
+	data := sampleLog(100000)
+	filename := writeTempLog(b, data)
+	b.SetBytes(int64(len(data)))
+	for b.Loop() {
+		parser, err := NewMmapParser(filename)
+		if err != nil {
+			b.Fatalf("failed to create parser: %v", err)
+		}
+		for {
+			if _, err := parser.ReadMessage(); err != nil {
+				break
+			}
+		}
+		parser.Close()
+	}
+}
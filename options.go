@@ -0,0 +1,149 @@
+package dataflash
+
+import "runtime"
+
+// defaultResyncDepth is the number of chained headers that confirm a resync
+// by default.
+const defaultResyncDepth = 2
+
+// Option configures a Parser.
+type Option func(*options)
+
+// options holds the settings applied by Option values.
+type options struct {
+	prescan      bool // Read all schema messages before returning from the constructor
+	bufferSize   int  // Size of the read buffer in bytes
+	indexEvery   int  // Number of messages per index entry
+	indexCache   bool // Load and save sidecar index files
+	workers      int  // Number of goroutines decoding chunks in a ParallelReader
+	chunkSize    int  // Size of the chunks a ParallelReader splits the log into
+	progress     func(Progress)
+	mode         ParseMode
+	resyncDepth  int // Number of chained headers that confirm a resync
+	schemaChange func(SchemaChange)
+}
+
+// defaultOptions returns the settings used when no options are given.
+func defaultOptions() options {
+	return options{
+		prescan:     true,
+		bufferSize:  defaultBufferSize,
+		indexEvery:  1,
+		indexCache:  true,
+		workers:     runtime.GOMAXPROCS(0),
+		chunkSize:   defaultChunkSize,
+		resyncDepth: defaultResyncDepth,
+	}
+}
+
+// readBufferSize returns the size of the read buffer: the buffer size option,
+// enlarged if needed so that a resync can peek at the whole header chain.
+func (o *options) readBufferSize() int {
+	return max(o.bufferSize, (o.resyncDepth-1)*maxMessageLength+HeaderSize)
+}
+
+// WithPrescan controls whether the parser makes a first pass over the log to
+// collect every FMT, FMTU, UNIT and MULT message before reading data.
+//
+// Pre-scanning is enabled by default: GetSchemas is complete from the start
+// and SetFilter can reject names that do not occur in the log. Disabling it
+// reads each log only once; schemas are then discovered as ReadMessage
+// encounters them, GetSchemas reflects what has been seen so far and SetFilter
+// accepts any name.
+func WithPrescan(enabled bool) Option {
+	return func(o *options) {
+		o.prescan = enabled
+	}
+}
+
+// WithBufferSize sets the size of the parser's read buffer in bytes.
+// Larger buffers mean fewer reads from the underlying source. The default is
+// 64 KiB; sizes below 512 bytes are rounded up.
+func WithBufferSize(size int) Option {
+	return func(o *options) {
+		o.bufferSize = size
+	}
+}
+
+// WithIndexInterval makes BuildIndex record one entry every n messages
+// instead of one per message. Larger intervals use less memory; seeking then
+// reads up to n-1 extra message headers.
+func WithIndexInterval(n int) Option {
+	return func(o *options) {
+		o.indexEvery = max(n, 1)
+	}
+}
+
+// WithIndexCache controls the sidecar index file (the log file name with
+// ".idx" appended) of parsers created from a file name. It is enabled by
+// default: the parser loads the sidecar if it matches the log, rebuilds and
+// rewrites it if it is stale, and BuildIndex saves it. Only parsers that
+// pre-scan the log use the cache, as the schema hash is needed to validate it.
+func WithIndexCache(enabled bool) Option {
+	return func(o *options) {
+		o.indexCache = enabled
+	}
+}
+
+// WithWorkers sets the number of goroutines a ParallelReader decodes chunks
+// on. The default is GOMAXPROCS.
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = max(n, 1)
+	}
+}
+
+// WithChunkSize sets the size in bytes of the chunks a ParallelReader splits
+// the log into. The default is 4 MiB.
+func WithChunkSize(size int) Option {
+	return func(o *options) {
+		o.chunkSize = max(size, 1)
+	}
+}
+
+// WithProgress registers fn to be called as the parser works through the log:
+// while pre-scanning schemas, building the index and reading messages.
+// fn is called roughly once per MiB read and once at the end of each pass,
+// on the goroutine doing the reading.
+func WithProgress(fn func(Progress)) Option {
+	return func(o *options) {
+		o.progress = fn
+	}
+}
+
+// WithParseMode sets how the parser reacts to corrupt data. The default,
+// Tolerant, skips it and records it in Diagnostics; Strict fails instead.
+func WithParseMode(mode ParseMode) Option {
+	return func(o *options) {
+		o.mode = mode
+	}
+}
+
+// WithResyncDepth sets how many consecutive valid headers, counting the
+// candidate itself, must follow each other before the parser accepts a
+// header it found while resynchronising after corrupt data. Each must have a
+// known schema and start where the message before it ends, or the chain must
+// reach the end of the log. The default is 2: the candidate and the header
+// after it. Deeper checks make false matches in payloads less likely, but
+// lose more messages around corrupt data. 1 only checks the candidate's type.
+//
+// The whole chain must fit in the read buffer, so the buffer is enlarged to
+// (n-1)*255+3 bytes if WithBufferSize sets a smaller one; with the default
+// 64 KiB buffer that is depths up to 257.
+func WithResyncDepth(n int) Option {
+	return func(o *options) {
+		o.resyncDepth = max(n, 1)
+	}
+}
+
+// WithSchemaChange registers fn to be called when a FMT message defines a
+// message type or redefines it with a different layout, as happens late in a
+// log when a subsystem starts logging, or in logs concatenated from several
+// boots. With the default pre-scan every change is reported while the parser
+// is created; otherwise as the FMT messages are read. Each FMT message is
+// reported once.
+func WithSchemaChange(fn func(SchemaChange)) Option {
+	return func(o *options) {
+		o.schemaChange = fn
+	}
+}
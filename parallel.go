@@ -0,0 +1,252 @@
+package dataflash
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	defaultChunkSize = 4 << 20 // Default ParallelReader chunk size
+	alignDepth       = 4       // Headers that must chain up to accept a chunk start
+	alignWindow      = 64 << 10
+)
+
+// ParallelReader decodes a log file on several goroutines. The file is split
+// into byte ranges whose starts are moved to message boundaries, the ranges
+// are decoded concurrently, and messages are delivered in their original
+// order with the same LineNo as Parser.ReadMessage would give them.
+//
+// A chunk start is only accepted where several consecutive headers chain up
+// by their schema lengths. Each chunk is read until the first message at or
+// after the next chunk's start, and if it does not stop exactly there the
+// next chunk is decoded again from where it did stop. Messages are therefore
+// identical to a sequential read even in corrupted regions; misaligned
+// chunks only cost time.
+type ParallelReader struct {
+	file *os.File
+	size int64
+	base *Parser // Pre-scanned parser holding the schemas and filter
+	opts options
+}
+
+// NewParallelReader creates a parallel reader for the given DataFlash log
+// file. The schemas are always collected in a first pass, since every chunk
+// needs them; WithPrescan has no effect.
+func NewParallelReader(filename string, opts ...Option) (*ParallelReader, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.prescan = true
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	section := io.NewSectionReader(file, 0, info.Size())
+	base, err := newSeekableParser(context.Background(), newBufferedReader(section, section, o.readBufferSize()), o)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &ParallelReader{
+		file: file,
+		size: info.Size(),
+		base: base,
+		opts: o,
+	}, nil
+}
+
+// Close closes the underlying file.
+func (r *ParallelReader) Close() error {
+	return r.file.Close()
+}
+
+// GetSchemas returns a map of all message schemas found in the log.
+func (r *ParallelReader) GetSchemas() map[uint8]*Schema {
+	return r.base.GetSchemas()
+}
+
+// SetFilter restricts the messages delivered to the given names.
+// Returns an error under the same conditions as Parser.SetFilter.
+func (r *ParallelReader) SetFilter(names ...string) error {
+	return r.base.SetFilter(names...)
+}
+
+// ClearFilter removes the filter set by SetFilter.
+func (r *ParallelReader) ClearFilter() {
+	r.base.ClearFilter()
+}
+
+// chunkResult holds the decoded messages of one chunk.
+type chunkResult struct {
+	messages []*Message
+	count    int64 // Messages in the chunk, including filtered ones
+	start    int64 // Offset the chunk was decoded from
+	end      int64 // Offset of the next chunk's start
+	next     int64 // Offset where decoding stopped
+	err      error
+}
+
+// Each calls fn for every message in log order. It stops at the first error
+// returned by fn or encountered while reading, and returns it.
+// At most as many chunks as there are workers are held in memory.
+func (r *ParallelReader) Each(fn func(*Message) error) error {
+	chunkSize := int64(r.opts.chunkSize)
+	chunks := int((r.size + chunkSize - 1) / chunkSize)
+
+	results := make([]chan chunkResult, chunks)
+	for i := range results {
+		results[i] = make(chan chunkResult, 1)
+	}
+
+	// Start chunks in order, keeping at most workers of them undelivered
+	slots := make(chan struct{}, r.opts.workers)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for i := range chunks {
+			select {
+			case slots <- struct{}{}:
+			case <-done:
+				return
+			}
+			go func() {
+				results[i] <- r.decodeChunk(int64(i)*chunkSize, int64(i+1)*chunkSize)
+			}()
+		}
+	}()
+
+	var lineNo, next int64
+	for i := range chunks {
+		result := <-results[i]
+		<-slots
+		if result.err == nil && result.start != next {
+			// The previous chunk did not stop at this chunk's start
+			result = r.decodeRange(next, result.end)
+		}
+		for _, msg := range result.messages {
+			msg.LineNo += lineNo
+			if err := fn(msg); err != nil {
+				return err
+			}
+		}
+		if result.err != nil {
+			return result.err
+		}
+		lineNo += result.count
+		next = result.next
+	}
+
+	return nil
+}
+
+// decodeChunk decodes the messages starting in [start, end), after moving
+// both bounds to message boundaries. LineNo values are relative to the chunk.
+func (r *ParallelReader) decodeChunk(start, end int64) chunkResult {
+	start, err := r.align(start)
+	if err != nil {
+		return chunkResult{err: err}
+	}
+	end, err = r.align(end)
+	if err != nil {
+		return chunkResult{err: err}
+	}
+	return r.decodeRange(start, end)
+}
+
+// decodeRange decodes messages from start until the first message at or
+// after end. LineNo values are relative to start.
+func (r *ParallelReader) decodeRange(start, end int64) chunkResult {
+	result := chunkResult{start: start, end: end, next: start}
+	if start >= end {
+		return result
+	}
+
+	section := io.NewSectionReader(r.file, 0, r.size)
+	p := r.base.view(newBufferedReader(section, section, r.opts.readBufferSize()))
+	if err := p.r.seek(start); err != nil {
+		result.err = err
+		return result
+	}
+	p.limit = end
+
+	for {
+		msg, err := p.ReadMessage()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			result.err = err
+			break
+		}
+		result.messages = append(result.messages, msg)
+	}
+	result.count = p.lineNo
+	result.next = p.r.pos()
+
+	return result
+}
+
+// align returns the first message boundary at or after offset.
+func (r *ParallelReader) align(offset int64) (int64, error) {
+	if offset <= 0 {
+		return 0, nil
+	}
+
+	buf := make([]byte, alignWindow)
+	for offset < r.size {
+		n, err := r.file.ReadAt(buf, offset)
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+
+		for i := 0; i+1 < n; {
+			j := bytes.Index(buf[i:n], magic)
+			if j < 0 {
+				break
+			}
+			candidate := offset + int64(i+j)
+			ok, err := r.chains(candidate)
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				return candidate, nil
+			}
+			i += j + 1
+		}
+
+		// Keep the last byte in case it is the first half of a header
+		if n < 2 {
+			break
+		}
+		offset += int64(n - 1)
+	}
+
+	return r.size, nil
+}
+
+// chains reports whether a chunk can start at offset, see headerChain.
+func (r *ParallelReader) chains(offset int64) (bool, error) {
+	header := make([]byte, HeaderSize)
+	return r.base.headerChain(offset, alignDepth, func(offset int64) ([]byte, error) {
+		if _, err := r.file.ReadAt(header, offset); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				err = io.EOF
+			}
+			return nil, err
+		}
+		return header, nil
+	})
+}
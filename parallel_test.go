@@ -0,0 +1,132 @@
+package dataflash
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// readAll returns every message of p in order.
+func readAll(t *testing.T, p *Parser) []*Message {
+	t.Helper()
+	var messages []*Message
+	for {
+		msg, err := p.ReadMessage()
+		if err != nil {
+			return messages
+		}
+		messages = append(messages, msg)
+	}
+}
+
+// trickyLog returns a log whose IMU payloads contain header-like byte
+// sequences that a chunk must not start at.
+func trickyLog(n int) []byte {
+	var l testLog
+	l.writePreamble()
+	for i := range n {
+		timeUS := uint64(1000 * (i + 1))
+		l.writeMessage(testIMUType, timeUS, uint8(0),
+			[4]byte{HEAD1, HEAD2, testIMUType, 0}, [4]byte{HEAD1, HEAD2, testGPSType, 0}, float32(0.3))
+		if i%7 == 0 {
+			l.writeGPS(timeUS, 50.45, 30.52)
+		}
+		if i%100 == 0 {
+			l.Write([]byte{0x00, HEAD1, 0x01})
+		}
+	}
+	return l.Bytes()
+}
+
+func TestParallelReader(t *testing.T) {
+	logs := map[string][]byte{
+		"sample":    sampleLog(5000),
+		"corrupt":   corruptLog(2000),
+		"tricky":    trickyLog(3000),
+		"redefined": redefinedLog(2000),
+	}
+
+	for name, data := range logs {
+		filename := writeTempLog(t, data)
+		for _, filter := range [][]string{nil, {"GPS"}} {
+			sequential, err := NewParser(filename)
+			if err != nil {
+				t.Fatalf("failed to create parser: %v", err)
+			}
+			reader, err := NewParallelReader(filename, WithWorkers(4), WithChunkSize(1000))
+			if err != nil {
+				t.Fatalf("failed to create parallel reader: %v", err)
+			}
+			if filter != nil {
+				if err := sequential.SetFilter(filter...); err != nil {
+					t.Fatalf("failed to set filter: %v", err)
+				}
+				if err := reader.SetFilter(filter...); err != nil {
+					t.Fatalf("failed to set filter: %v", err)
+				}
+			}
+
+			want := readAll(t, sequential)
+			var got []*Message
+			err = reader.Each(func(msg *Message) error {
+				got = append(got, msg)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("%s: error reading in parallel: %v", name, err)
+			}
+
+			if len(got) != len(want) {
+				t.Fatalf("%s %v: got %d messages, want %d", name, filter, len(got), len(want))
+			}
+			for i := range want {
+				if !reflect.DeepEqual(got[i], want[i]) {
+					t.Fatalf("%s %v: message %d = %+v, want %+v", name, filter, i, got[i], want[i])
+				}
+			}
+
+			sequential.Close()
+			reader.Close()
+		}
+	}
+}
+
+func TestParallelReaderStopsOnError(t *testing.T) {
+	reader, err := NewParallelReader(writeTempLog(t, sampleLog(5000)), WithWorkers(2), WithChunkSize(500))
+	if err != nil {
+		t.Fatalf("failed to create parallel reader: %v", err)
+	}
+	defer reader.Close()
+
+	stop := errors.New("stop")
+	count := 0
+	err = reader.Each(func(msg *Message) error {
+		count++
+		if count == 100 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Errorf("expected callback error, got %v", err)
+	}
+	if count != 100 {
+		t.Errorf("expected 100 callbacks, got %d", count)
+	}
+}
+
+func BenchmarkParallelReader(b *testing.B) {
+	data := sampleLog(100000)
+	filename := writeTempLog(b, data)
+	b.SetBytes(int64(len(data)))
+	for b.Loop() {
+		reader, err := NewParallelReader(filename, WithChunkSize(256<<10))
+		if err != nil {
+			b.Fatalf("failed to create parallel reader: %v", err)
+		}
+		if err := reader.Each(func(*Message) error { return nil }); err != nil {
+			b.Fatalf("error reading: %v", err)
+		}
+		reader.Close()
+	}
+}
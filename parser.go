@@ -1,10 +1,13 @@
 package dataflash
 
 import (
-	"encoding/binary"
+	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"os"
+	"strings"
 )
 
 // DataFlash binary format constants
@@ -16,150 +19,328 @@ const (
 	HeaderSize = 3    // Header size in bytes
 )
 
+// ErrNotSeekable is returned by operations that need to reposition the
+// underlying reader when the parser was created with NewStreamParser.
+var ErrNotSeekable = errors.New("reader does not support seeking")
+
+// errInvalidHeader is returned by readMessageHeader for bytes that are not a
+// message header, as opposed to errors reading the input.
+var errInvalidHeader = errors.New("invalid header")
+
+// fmtSchema describes the FMT message itself. Logs normally start with a
+// self-describing FMT record, but the layout is fixed so a stream parser can
+// decode FMT messages before it has seen one.
+var fmtSchema = func() *Schema {
+	s := &Schema{
+		Type:    FMTType,
+		Length:  FMTLength,
+		Name:    "FMT",
+		Format:  "BBnNZ",
+		Columns: "Type,Length,Name,Format,Columns",
+	}
+	s.compile()
+	return s
+}()
+
 // Parser reads and parses ArduPilot DataFlash binary logs.
 type Parser struct {
-	file        *os.File
-	schemas     map[uint8]*Schema
-	filterTypes map[uint8]bool
+	r           logReader
+	closer      io.Closer // nil when the caller owns the reader
+	opts        options
+	schemas     map[uint8]*Schema         // Latest definition of each type
+	versions    map[uint8][]schemaVersion // Every definition of each type, by offset
+	units       map[rune]string           // Unit names from UNIT messages
+	mults       map[rune]float64          // Multipliers from MULT messages
+	filterNames map[string]bool
 	lineNo      int64 // Current message sequence number
+	offset      int64 // Byte offset of the current message header
+	limit       int64 // Messages starting at or after this offset are not read, 0 for no limit
+	start       int64 // Offset Rewind returns to, 0 except in segment views
+	startLineNo int64 // LineNo of the message before start
+	index       *Index
+	segments    []Segment // Boots found by Segments, nil until then
+	filename    string    // Log file whose index is cached, empty if not cached
+	fmtHash     uint64    // Hash of all FMT messages, set by the pre-scan
+	progressAt  int64     // Offset at which progress is reported next
+	lazy        bool      // Schemas are discovered while reading messages
+	diag        Diagnostics
+	seen        map[int64]bool // Offsets of the anomalies recorded in diag
 }
 
 // NewParser creates a new parser for the given DataFlash log file.
 // It performs a first pass to build the schema map from FMT messages.
 func NewParser(filename string) (*Parser, error) {
+	return NewParserWithOptions(filename)
+}
+
+// NewParserWithOptions creates a new parser for the given DataFlash log file
+// configured by opts.
+func NewParserWithOptions(filename string, opts ...Option) (*Parser, error) {
+	return NewParserContext(context.Background(), filename, opts...)
+}
+
+// NewParserContext is like NewParserWithOptions but stops the pre-scan (and
+// rebuilding a stale sidecar index) with ctx.Err() once ctx is done.
+func NewParserContext(ctx context.Context, filename string, opts ...Option) (*Parser, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	file, err := os.Open(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 
-	p := &Parser{
-		file:    file,
-		schemas: make(map[uint8]*Schema),
+	p, err := newSeekableParser(ctx, newBufferedReader(file, file, o.readBufferSize()), o)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	p.closer = file
+
+	if err := p.useIndexCache(ctx, filename); err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// NewParserFromReader creates a new parser reading from r.
+// Like NewParser it performs a first pass to build the schema map unless
+// WithPrescan(false) is given, then seeks back to the start of r.
+// The caller remains responsible for closing r.
+func NewParserFromReader(r io.ReadSeeker, opts ...Option) (*Parser, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return newSeekableParser(context.Background(), newBufferedReader(r, r, o.readBufferSize()), o)
+}
+
+// NewParserFromBytes creates a new parser reading from an in-memory log.
+// Message bodies are decoded directly from data, which must not be modified
+// while the parser is in use. WithBufferSize has no effect.
+func NewParserFromBytes(data []byte, opts ...Option) (*Parser, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return newSeekableParser(context.Background(), &memoryReader{data: data}, o)
+}
+
+// newSeekableParser returns a parser reading from r, building the schema map
+// first if o asks for a pre-scan.
+func newSeekableParser(ctx context.Context, r logReader, o options) (*Parser, error) {
+	p := newParser(r, o)
+	if !o.prescan {
+		p.lazy = true
+		return p, nil
 	}
 
 	// Pass 1: Build schema map from FMT messages
-	if err := p.buildSchemas(); err != nil {
-		file.Close()
+	if err := p.buildSchemas(ctx); err != nil {
 		return nil, fmt.Errorf("failed to build schemas: %w", err)
 	}
 
 	// Rewind for reading messages
-	if _, err := file.Seek(0, io.SeekStart); err != nil {
-		file.Close()
-		return nil, fmt.Errorf("failed to rewind file: %w", err)
+	if err := p.Rewind(); err != nil {
+		return nil, fmt.Errorf("failed to rewind reader: %w", err)
 	}
 
 	return p, nil
 }
 
-// Close closes the underlying file.
+// NewStreamParser creates a forward-only parser reading from r.
+// No first pass is made: schemas are built as schema messages are read,
+// so GetSchemas only reflects the part of the log consumed so far.
+// Rewind and GetSlice return ErrNotSeekable. If r implements io.Closer,
+// it is not closed by Close; the caller remains responsible for it.
+// WithPrescan has no effect on stream parsers.
+func NewStreamParser(r io.Reader, opts ...Option) *Parser {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	p := newParser(newBufferedReader(r, nil, o.readBufferSize()), o)
+	p.lazy = true
+	return p
+}
+
+// newParser returns a parser reading from r with empty schema state.
+func newParser(r logReader, o options) *Parser {
+	return &Parser{
+		r:        r,
+		opts:     o,
+		schemas:  make(map[uint8]*Schema),
+		versions: make(map[uint8][]schemaVersion),
+		units:    make(map[rune]string),
+		mults:    make(map[rune]float64),
+	}
+}
+
+// view returns a parser reading from r that shares the schemas and filter
+// of p. Schemas are not updated by the view, so several views can be used
+// concurrently. Views do not report progress.
+func (p *Parser) view(r logReader) *Parser {
+	v := newParser(r, p.opts)
+	v.opts.progress = nil
+	// Chunks may start inside corrupt data the pre-scan already checked
+	v.opts.mode = Tolerant
+	v.schemas = p.schemas
+	v.versions = p.versions
+	v.units = p.units
+	v.mults = p.mults
+	v.filterNames = p.filterNames
+	return v
+}
+
+// Close closes the underlying file if the parser opened it.
 func (p *Parser) Close() error {
-	return p.file.Close()
+	if p.closer == nil {
+		return nil
+	}
+	err := p.closer.Close()
+	p.closer = nil
+	return err
 }
 
 // GetSchemas returns a map of all message schemas found in the log.
+// Without a pre-scan it only contains the schemas read so far. A type that
+// is redefined mid-log maps to its last definition; see SchemaAt.
 func (p *Parser) GetSchemas() map[uint8]*Schema {
 	return p.schemas
 }
 
+// Units returns the unit names defined by UNIT messages in the log, keyed by
+// unit identifier. Without a pre-scan it only contains the units read so far.
+func (p *Parser) Units() map[rune]string {
+	return p.units
+}
+
+// Multipliers returns the multipliers defined by MULT messages in the log,
+// keyed by multiplier identifier. Without a pre-scan it only contains the
+// multipliers read so far.
+func (p *Parser) Multipliers() map[rune]float64 {
+	return p.mults
+}
+
 // ReadMessage reads and parses the next message from the log.
 // Returns io.EOF when there are no more messages.
 func (p *Parser) ReadMessage() (*Message, error) {
-	for {
-		msgType, err := p.readMessageHeader()
-		if err == io.EOF || err == io.ErrUnexpectedEOF {
-			return nil, err
-		}
-		if err != nil {
-			// Invalid header - try to sync to next valid header
-			if syncErr := p.syncToNextHeader(); syncErr != nil {
-				if syncErr == io.EOF || syncErr == io.ErrUnexpectedEOF {
-					return nil, syncErr
-				}
-				// Continue trying to read next message
-			}
-			continue
+	msgType, schema, body, err := p.nextBody()
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode message body
+	fields, err := DecodeMessageBody(body, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode message: %w", err)
+	}
+
+	// Extract TimeUS if available
+	timeUS := int64(0)
+	if val, ok := fields["TimeUS"]; ok {
+		switch v := val.(type) {
+		case int64:
+			timeUS = v
+		case uint64:
+			timeUS = int64(v)
 		}
+	}
 
-		// Check if we have schema for this message type
-		schema, ok := p.schemas[msgType]
-		if !ok {
-			// Unknown message type - sync to next header
-			if syncErr := p.syncToNextHeader(); syncErr != nil {
-				if syncErr == io.EOF || syncErr == io.ErrUnexpectedEOF {
-					return nil, syncErr
-				}
+	return &Message{
+		Type:   msgType,
+		Name:   schema.Name,
+		Fields: fields,
+		LineNo: p.lineNo,
+		TimeUS: timeUS,
+		Offset: p.offset,
+		schema: schema,
+	}, nil
+}
+
+// nextBody reads up to the next message that passes the filter and returns
+// its body, absorbing schema messages along the way when schemas are
+// discovered lazily. The body points into the read buffer and is only valid
+// until the next read.
+func (p *Parser) nextBody() (uint8, *Schema, []byte, error) {
+	for {
+		msgType, schema, err := p.nextHeader()
+		if err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				p.reportProgress(StageMessages, p.lineNo, true)
 			}
-			continue
+			return 0, nil, nil, err
 		}
 
 		// Increment line number for every message
 		p.lineNo++
+		p.reportProgress(StageMessages, p.lineNo, false)
 
-		// Check filter before reading body
-		if p.filterTypes != nil && !p.filterTypes[msgType] {
-			bodySize := int(schema.Length) - HeaderSize
-			p.file.Seek(int64(bodySize), io.SeekCurrent)
-			continue
-		}
-
-		// Read message body
+		// Check filter before reading body. Without a pre-scan, schema
+		// messages still have to be read to learn the schemas.
 		bodySize := int(schema.Length) - HeaderSize
-		body := make([]byte, bodySize)
-		if _, err := io.ReadFull(p.file, body); err != nil {
-			return nil, err
+		filtered := p.filterNames != nil && !p.filterNames[schema.Name]
+		absorb := p.lazy && isSchemaMessage(schema.Name)
+		if filtered && !absorb {
+			if err := p.r.discard(int64(bodySize)); err != nil {
+				return 0, nil, nil, p.truncated(p.offset, err)
+			}
+			continue
 		}
 
-		// Decode message body
-		fields, err := DecodeMessageBody(body, schema)
+		body, err := p.r.next(bodySize)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode message: %w", err)
+			return 0, nil, nil, p.truncated(p.offset, err)
 		}
 
-		// Extract TimeUS if available
-		timeUS := int64(0)
-		if val, ok := fields["TimeUS"]; ok {
-			switch v := val.(type) {
-			case int64:
-				timeUS = v
-			case uint64:
-				timeUS = int64(v)
+		if absorb {
+			if err := p.absorb(schema, body); err != nil {
+				return 0, nil, nil, err
 			}
 		}
+		if filtered {
+			continue
+		}
 
-		return &Message{
-			Type:   msgType,
-			Name:   schema.Name,
-			Fields: fields,
-			LineNo: p.lineNo,
-			TimeUS: timeUS,
-			schema: schema,
-		}, nil
+		return msgType, schema, body, nil
 	}
 }
 
 // SetFilter creates filter rule to parse specific message names.
 // Automatically rewinds the file to the beginning so all messages are available.
 // Returns an error if none of the provided names match any message types in the log.
+// Stream parsers cannot validate names up front or rewind, so for them the
+// filter is applied from the current position to schemas as they are discovered.
 func (p *Parser) SetFilter(names ...string) error {
-	p.filterTypes = make(map[uint8]bool)
-	var invalidNames []string
+	p.filterNames = make(map[string]bool)
 
-	for _, name := range names {
-		found := false
-		for typ, schema := range p.schemas {
-			if schema.Name == name {
-				p.filterTypes[typ] = true
-				found = true
-				break
-			}
+	if p.lazy {
+		for _, name := range names {
+			p.filterNames[name] = true
 		}
-		if !found {
+		if !p.r.seekable() {
+			return nil
+		}
+		return p.Rewind()
+	}
+
+	var invalidNames []string
+	for _, name := range names {
+		if p.hasSchema(name) {
+			p.filterNames[name] = true
+		} else {
 			invalidNames = append(invalidNames, name)
 		}
 	}
 
-	if len(p.filterTypes) == 0 {
+	if len(p.filterNames) == 0 {
 		return fmt.Errorf("no valid message types found in filter: %v", names)
 	}
 
@@ -168,21 +349,23 @@ func (p *Parser) SetFilter(names ...string) error {
 	}
 
 	// Rewind to start so filter applies from beginning
-	p.lineNo = 0
-	_, err := p.file.Seek(0, io.SeekStart)
-	return err
+	return p.Rewind()
 }
 
 func (p *Parser) ClearFilter() {
-	p.filterTypes = nil
+	p.filterNames = nil
 }
 
 // Rewind resets the file position to the beginning.
 // Useful for re-reading messages or starting a new iteration.
+// Returns ErrNotSeekable for stream parsers.
 func (p *Parser) Rewind() error {
-	p.lineNo = 0
-	_, err := p.file.Seek(0, io.SeekStart)
-	return err
+	if err := p.r.seek(p.start); err != nil {
+		return err
+	}
+	p.lineNo = p.startLineNo
+	p.progressAt = 0
+	return nil
 }
 
 // SliceType specifies how to slice the log.
@@ -196,195 +379,342 @@ const (
 // GetSlice returns messages within the specified range.
 // start and end values are interpreted based on sliceType (LineNo or TimeUS).
 // The returned messages are those where start <= value < end.
+// After BuildIndex the scan starts at start instead of the beginning of the
+// log, so slicing takes time proportional to the size of the slice.
 func (p *Parser) GetSlice(start, end int64, sliceType SliceType) ([]*Message, error) {
-	if err := p.Rewind(); err != nil {
-		return nil, err
-	}
+	return p.GetSliceContext(context.Background(), start, end, sliceType)
+}
 
+// GetSliceContext is like GetSlice but stops with ctx.Err() once ctx is done.
+func (p *Parser) GetSliceContext(ctx context.Context, start, end int64, sliceType SliceType) ([]*Message, error) {
 	var messages []*Message
+	for msg, err := range p.slice(ctx, start, end, sliceType) {
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, nil
+}
+
+// buildSchemas performs the first pass to read all FMT, FMTU, UNIT and MULT messages.
+// A message that precedes the FMT of its type can't be framed until that FMT
+// has been read, so when the pass skipped such messages it is repeated with
+// the schemas found. For the same reason a Strict parser scans tolerantly
+// first, and repeats the pass to fail at the first anomaly.
+func (p *Parser) buildSchemas(ctx context.Context) error {
+	var messages int64
+	defer func() { p.reportProgress(StageSchemas, messages, true) }()
+
+	mode := p.opts.mode
+	p.opts.mode = Tolerant
+	err := p.scanSchemas(ctx, &messages)
+	p.opts.mode = mode
+	if err != nil || !p.rescanSchemas() {
+		return err
+	}
+
+	p.diag = Diagnostics{}
+	p.seen = nil
+	if err := p.r.seek(p.start); err != nil {
+		return err
+	}
+	messages = 0
+	return p.scanSchemas(ctx, &messages)
+}
+
+// rescanSchemas reports whether the schema pass must be repeated: it skipped
+// messages of a type defined later in the log, or found anomalies a Strict
+// parser must fail at.
+func (p *Parser) rescanSchemas() bool {
+	if p.opts.mode == Strict && len(p.seen) > 0 {
+		return true
+	}
+	for typ := range p.diag.UnknownTypes {
+		if len(p.versions[typ]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// scanSchemas reads the schema messages from the current position to the
+// end of the log, counting the messages read in messages.
+func (p *Parser) scanSchemas(ctx context.Context, messages *int64) error {
+	hash := fnv.New64a()
+	defer func() { p.fmtHash = hash.Sum64() }()
+
 	for {
-		msg, err := p.ReadMessage()
+		if err := checkContext(ctx, *messages); err != nil {
+			return err
+		}
+
+		_, schema, err := p.nextHeader()
 		if err == io.EOF || err == io.ErrUnexpectedEOF {
 			break
 		}
 		if err != nil {
-			return nil, err
+			return err
 		}
+		*messages++
+		p.reportProgress(StageSchemas, *messages, false)
 
-		var value int64
-		switch sliceType {
-		case SliceByLineNo:
-			value = msg.LineNo
-		case SliceByTimeUS:
-			value = msg.TimeUS
-		default:
-			return nil, fmt.Errorf("invalid slice type: %s", sliceType)
+		bodySize := int(schema.Length) - HeaderSize
+		if !isSchemaMessage(schema.Name) {
+			if err := p.r.discard(int64(bodySize)); err != nil {
+				if err = p.truncated(p.offset, err); err == io.EOF || err == io.ErrUnexpectedEOF {
+					break
+				}
+				return err
+			}
+			continue
 		}
 
-		if value >= start && value < end {
-			messages = append(messages, msg)
+		body, err := p.r.next(bodySize)
+		if err != nil {
+			if err = p.truncated(p.offset, err); err == io.EOF || err == io.ErrUnexpectedEOF {
+				break
+			}
+			return err
+		}
+		if schema.Name == "FMT" {
+			hashFMT(hash, body)
 		}
 
-		// Early exit if we've passed the end
-		if value >= end {
-			break
+		if err := p.absorb(schema, body); err != nil {
+			return err
 		}
 	}
 
-	return messages, nil
+	return nil
 }
 
-// buildSchemas performs the first pass to read all FMT and FMTU messages.
-func (p *Parser) buildSchemas() error {
+// nextHeader reads headers until it finds a message with a known schema,
+// resyncing past invalid headers and unknown message types.
+// The message body is left unread.
+func (p *Parser) nextHeader() (uint8, *Schema, error) {
 	for {
+		if p.limit > 0 && p.r.pos() >= p.limit {
+			return 0, nil, io.EOF
+		}
+
 		msgType, err := p.readMessageHeader()
 		if err == io.EOF || err == io.ErrUnexpectedEOF {
-			break
+			return 0, nil, p.truncated(p.r.pos(), err)
+		}
+		if err != nil && err != errInvalidHeader {
+			return 0, nil, err
 		}
 		if err != nil {
-			// Skip invalid headers
+			// Invalid header - try to sync to next valid header
+			if syncErr := p.resync(0, false); syncErr != nil {
+				return 0, nil, syncErr
+			}
 			continue
 		}
 
-		if msgType == FMTType {
-			schema, err := p.decodeFMTMessage()
-			if err != nil {
-				return err
-			}
-			p.schemas[schema.Type] = schema
-		} else if schema, exists := p.schemas[msgType]; exists && schema.Name == "FMTU" {
-			// Decode FMTU message to get units and multipliers
-			bodySize := int(schema.Length) - HeaderSize
-			body := make([]byte, bodySize)
-			if _, err := io.ReadFull(p.file, body); err != nil {
-				continue
+		// Check if we have schema for this message type
+		schema, ok := p.lookupSchema(msgType, p.r.pos())
+		if !ok {
+			// Unknown message type - sync to next header
+			if syncErr := p.resync(msgType, true); syncErr != nil {
+				return 0, nil, syncErr
 			}
+			continue
+		}
 
-			fields, err := DecodeMessageBody(body, schema)
-			if err != nil {
-				// Skip malformed FMTU messages
-				continue
-			}
+		p.offset = p.r.pos()
+		p.r.discard(HeaderSize)
+		return msgType, schema, nil
+	}
+}
 
-			// Extract FmtType, UnitIds, MultIds fields
-			fmtType, ok := fields["FmtType"].(uint8)
-			if !ok {
-				continue
+// lookupSchema returns the schema for a message of msgType at offset, falling
+// back to the built-in FMT schema for FMT messages.
+func (p *Parser) lookupSchema(msgType uint8, offset int64) (*Schema, bool) {
+	if schema := p.SchemaAt(msgType, offset); schema != nil {
+		return schema, true
+	}
+	if msgType == FMTType {
+		return fmtSchema, true
+	}
+	return nil, false
+}
+
+// absorb decodes the body of a schema message and applies it.
+func (p *Parser) absorb(schema *Schema, body []byte) error {
+	fields, err := DecodeMessageBody(body, schema)
+	if err != nil {
+		// The built-in FMT schema always decodes, so this is a bad FMTU,
+		// UNIT or MULT schema that has already been recorded
+		return nil
+	}
+	return p.absorbSchemaMessage(schema.Name, fields)
+}
+
+// absorbSchemaMessage updates the schema map from a decoded FMT or FMTU
+// message, and the unit and multiplier tables from UNIT and MULT messages.
+// Invalid schemas and malformed FMTU messages are recorded in the
+// diagnostics; in strict mode they are returned as errors.
+func (p *Parser) absorbSchemaMessage(name string, fields map[string]any) error {
+	switch name {
+	case "FMT":
+		typ, _ := fields["Type"].(uint8)
+		length, _ := fields["Length"].(uint8)
+		schemaName, _ := fields["Name"].(string)
+		format, _ := fields["Format"].(string)
+		columns, _ := fields["Columns"].(string)
+		schema := &Schema{
+			Type:    typ,
+			Length:  length,
+			Name:    cString(schemaName),
+			Format:  cString(format),
+			Columns: cString(columns),
+		}
+		schema.compile()
+		if err := schema.Validate(); err != nil {
+			var schemaErr *SchemaError
+			errors.As(err, &schemaErr)
+			if p.anomaly(p.offset) {
+				p.diag.InvalidSchemas = append(p.diag.InvalidSchemas, schemaErr)
 			}
-			unitIds, ok := fields["UnitIds"].(string)
-			if !ok {
-				continue
+			if strictErr := p.corrupt(p.offset, "%w", err); strictErr != nil {
+				return strictErr
 			}
-			multIds, ok := fields["MultIds"].(string)
-			if !ok {
-				continue
+			// Messages of this type can't be framed at all
+			if int(length) < HeaderSize {
+				return nil
 			}
-
-			// Update the corresponding schema with units and multipliers
-			if targetSchema, exists := p.schemas[fmtType]; exists {
-				targetSchema.Units = unitIds
-				targetSchema.Mults = multIds
-				p.schemas[fmtType] = targetSchema
+			// Otherwise they are read but fail to decode
+			schema.err = err
+		}
+		p.addSchema(p.offset, schema)
+	case "FMTU":
+		fmtType, _ := fields["FmtType"].(uint8)
+		unitIds, _ := fields["UnitIds"].(string)
+		multIds, _ := fields["MultIds"].(string)
+		targetSchema := p.SchemaAt(fmtType, p.offset)
+		exists := targetSchema != nil
+		if !exists || len(unitIds) != len(targetSchema.Fields()) ||
+			len(multIds) != len(targetSchema.Fields()) {
+			if p.anomaly(p.offset) {
+				p.diag.MalformedFMTU = append(p.diag.MalformedFMTU, p.offset)
 			}
-		} else {
-			// Unknown message type - sync to next header
-			if err := p.syncToNextHeader(); err != nil {
-				if err == io.EOF || err == io.ErrUnexpectedEOF {
-					break
-				}
+			if err := p.corrupt(p.offset, "malformed FMTU for type %d", fmtType); err != nil {
 				return err
 			}
+			if !exists {
+				return nil
+			}
+		}
+		targetSchema.setUnits(unitIds, multIds)
+	case "UNIT":
+		id, ok := fields["Id"].(int8)
+		if !ok {
+			return nil
 		}
+		label, _ := fields["Label"].(string)
+		p.units[rune(id)] = label
+	case "MULT":
+		id, ok := fields["Id"].(int8)
+		if !ok {
+			return nil
+		}
+		mult, _ := fields["Mult"].(float64)
+		p.mults[rune(id)] = mult
 	}
-
 	return nil
 }
 
-// syncToNextHeader scans forward byte-by-byte to find the next valid message header.
-// This is used when we encounter unknown message types during schema building.
+// isSchemaMessage reports whether messages with the given name carry schema
+// information that must be read even when filtered out.
+func isSchemaMessage(name string) bool {
+	switch name {
+	case "FMT", "FMTU", "UNIT", "MULT":
+		return true
+	default:
+		return false
+	}
+}
+
+// cString returns s up to its first null byte.
+func cString(s string) string {
+	if i := strings.IndexByte(s, 0); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// syncToNextHeader skips the byte at the current position and advances to
+// the next header that starts a chain of valid headers, see headerChain.
+// This is used when we encounter invalid headers or unknown message types.
 func (p *Parser) syncToNextHeader() error {
 	for {
-		byte1 := make([]byte, 1)
-		_, err := p.file.Read(byte1)
-		if err != nil {
+		if err := p.r.discard(1); err != nil {
+			return err
+		}
+		if err := p.r.syncToHeader(); err != nil {
 			return err
 		}
 
-		if byte1[0] == HEAD1 {
-			// Found potential first magic byte, check second
-			byte2 := make([]byte, 1)
-			_, err := p.file.Read(byte2)
+		start := p.r.pos()
+		ok, err := p.headerChain(start, p.opts.resyncDepth, func(offset int64) ([]byte, error) {
+			b, err := p.r.peek(int(offset-start) + HeaderSize)
+			if err == io.ErrUnexpectedEOF {
+				err = io.EOF // Past the end of the log
+			}
 			if err != nil {
-				return err
+				return nil, err
 			}
+			return b[len(b)-HeaderSize:], nil
+		})
+		if err != nil || ok {
+			return err
+		}
+	}
+}
 
-			if byte2[0] == HEAD2 {
-				// Found valid header! Seek back 2 bytes so next read gets the full header
-				_, err := p.file.Seek(-2, io.SeekCurrent)
-				return err
-			}
-			// Second byte didn't match, seek back 1 and continue
-			p.file.Seek(-1, io.SeekCurrent)
+// headerChain reports whether a candidate header at offset is followed by
+// more valid headers, each at the end of the message before it, so that a
+// HEAD1 HEAD2 pair inside a payload is not mistaken for a message. The
+// candidate must have a known schema. It checks depth headers, including
+// the candidate, and accepts a chain that runs to the end of the log.
+// headerAt returns the header at an offset, or io.EOF if it cannot be read.
+func (p *Parser) headerChain(offset int64, depth int, headerAt func(offset int64) ([]byte, error)) (bool, error) {
+	for i := range depth {
+		header, err := headerAt(offset)
+		if err == io.EOF {
+			return true, nil
 		}
+		if err != nil {
+			return false, err
+		}
+		if header[0] != HEAD1 || header[1] != HEAD2 {
+			return false, nil
+		}
+		schema, ok := p.lookupSchema(header[2], offset)
+		if !ok {
+			// The candidate needs a known schema. A later header of an
+			// unknown type is valid, but the chain can't be followed past it.
+			return i > 0, nil
+		}
+		offset += int64(schema.Length)
 	}
+	return true, nil
 }
 
-// readMessageHeader reads and validates a 3-byte message header.
+// readMessageHeader peeks at and validates a 3-byte message header.
+// The header is left unread so that a resync can start inside it.
 func (p *Parser) readMessageHeader() (uint8, error) {
-	header := make([]byte, HeaderSize)
-	_, err := io.ReadFull(p.file, header)
+	header, err := p.r.peek(HeaderSize)
 	if err != nil {
 		return 0, err
 	}
 
 	if header[0] != HEAD1 || header[1] != HEAD2 {
-		return 0, fmt.Errorf("invalid header")
+		return 0, errInvalidHeader
 	}
 
 	return header[2], nil
 }
-
-// decodeFMTMessage reads and decodes a FMT message from the current file position.
-func (p *Parser) decodeFMTMessage() (*Schema, error) {
-	var schema Schema
-
-	if err := binary.Read(p.file, binary.LittleEndian, &schema.Type); err != nil {
-		return nil, fmt.Errorf("reading FMT type: %w", err)
-	}
-	if err := binary.Read(p.file, binary.LittleEndian, &schema.Length); err != nil {
-		return nil, fmt.Errorf("reading FMT length: %w", err)
-	}
-
-	var err error
-	schema.Name, err = readString(p.file, 4)
-	if err != nil {
-		return nil, err
-	}
-	schema.Format, err = readString(p.file, 16)
-	if err != nil {
-		return nil, err
-	}
-	schema.Columns, err = readString(p.file, 64)
-	if err != nil {
-		return nil, err
-	}
-
-	return &schema, nil
-}
-
-// readString reads a null-terminated string of maximum length from the file.
-func readString(file *os.File, maxLen int) (string, error) {
-	buf := make([]byte, maxLen)
-	_, err := file.Read(buf)
-	if err != nil {
-		return "", err
-	}
-
-	// Find null terminator
-	for i, b := range buf {
-		if b == 0 {
-			return string(buf[:i]), nil
-		}
-	}
-	return string(buf), nil
-}
@@ -1,7 +1,13 @@
 package dataflash
 
 import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -198,3 +204,485 @@ func TestGetSlice(t *testing.T) {
 		}
 	}
 }
+
+// testLog builds small synthetic DataFlash logs in memory so tests do not
+// depend on a recorded flight.
+type testLog struct {
+	bytes.Buffer
+}
+
+// writeFMT appends a FMT message describing schema.
+func (l *testLog) writeFMT(schema *Schema) {
+	l.Write([]byte{HEAD1, HEAD2, FMTType, schema.Type, schema.Length})
+	l.writeString(schema.Name, 4)
+	l.writeString(schema.Format, 16)
+	l.writeString(schema.Columns, 64)
+}
+
+// writeFMTU appends a FMTU message attaching units and multipliers to typ.
+func (l *testLog) writeFMTU(timeUS uint64, typ uint8, units, mults string) {
+	l.writeMessage(testFMTUType, timeUS, typ)
+	l.writeString(units, 16)
+	l.writeString(mults, 16)
+}
+
+// writeMessage appends a header followed by the little-endian encoding of values.
+func (l *testLog) writeMessage(typ uint8, values ...any) {
+	l.Write([]byte{HEAD1, HEAD2, typ})
+	for _, v := range values {
+		binary.Write(&l.Buffer, binary.LittleEndian, v)
+	}
+}
+
+// writeString appends s padded with zeros to n bytes.
+func (l *testLog) writeString(s string, n int) {
+	buf := make([]byte, n)
+	copy(buf, s)
+	l.Write(buf)
+}
+
+const (
+	testFMTUType = 129
+	testIMUType  = 130
+	testGPSType  = 131
+)
+
+var (
+	testFMTUSchema = &Schema{Type: testFMTUType, Length: 44, Name: "FMTU", Format: "QBNN", Columns: "TimeUS,FmtType,UnitIds,MultIds"}
+	testIMUSchema  = &Schema{Type: testIMUType, Length: 24, Name: "IMU", Format: "QBfff", Columns: "TimeUS,I,GyrX,GyrY,GyrZ"}
+	testGPSSchema  = &Schema{Type: testGPSType, Length: 24, Name: "GPS", Format: "QBLLe", Columns: "TimeUS,Status,Lat,Lng,Alt"}
+)
+
+// writePreamble appends the FMT and FMTU messages for the test schemas.
+func (l *testLog) writePreamble() {
+	l.writeFMT(fmtSchema)
+	l.writeFMT(testFMTUSchema)
+	l.writeFMT(testIMUSchema)
+	l.writeFMT(testGPSSchema)
+	l.writeFMTU(0, testIMUType, "s#EEE", "F-000")
+	l.writeFMTU(0, testGPSType, "s-DUm", "F-GGB")
+}
+
+// writeIMU appends an IMU message.
+func (l *testLog) writeIMU(timeUS uint64) {
+	l.writeMessage(testIMUType, timeUS, uint8(0), float32(0.1), float32(0.2), float32(0.3))
+}
+
+// writeGPS appends a GPS message.
+func (l *testLog) writeGPS(timeUS uint64, lat, lng float64) {
+	l.writeMessage(testGPSType, timeUS, uint8(3), int32(lat*1e7), int32(lng*1e7), int32(27530))
+}
+
+// sampleLog returns a log with the test preamble followed by n IMU messages
+// 1ms apart, with a GPS message after every tenth IMU message.
+// The log contains 6 + n + n/10 messages.
+func sampleLog(n int) []byte {
+	var l testLog
+	l.writePreamble()
+	for i := range n {
+		timeUS := uint64(1000 * (i + 1))
+		l.writeIMU(timeUS)
+		if (i+1)%10 == 0 {
+			l.writeGPS(timeUS, 50.45, 30.52)
+		}
+	}
+	return l.Bytes()
+}
+
+// streamOnly hides any Seek method of the wrapped reader.
+type streamOnly struct {
+	io.Reader
+}
+
+// countMessages reads p to the end and returns the number of messages per name.
+func countMessages(t *testing.T, p *Parser) map[string]int {
+	t.Helper()
+	counts := make(map[string]int)
+	for {
+		msg, err := p.ReadMessage()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return counts
+		}
+		if err != nil {
+			t.Fatalf("error reading message: %v", err)
+		}
+		counts[msg.Name]++
+	}
+}
+
+func TestNewParserFromReader(t *testing.T) {
+	parser, err := NewParserFromReader(bytes.NewReader(sampleLog(100)))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	defer parser.Close()
+
+	counts := countMessages(t, parser)
+	want := map[string]int{"FMT": 4, "FMTU": 2, "IMU": 100, "GPS": 10}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("got %v, want %v", counts, want)
+	}
+
+	// Readers support rewinding and slicing like files
+	messages, err := parser.GetSlice(7, 17, SliceByLineNo)
+	if err != nil {
+		t.Fatalf("error getting slice: %v", err)
+	}
+	if len(messages) != 10 {
+		t.Errorf("expected 10 messages, got %d", len(messages))
+	}
+}
+
+func TestStreamParser(t *testing.T) {
+	parser := NewStreamParser(streamOnly{bytes.NewReader(sampleLog(100))})
+	defer parser.Close()
+
+	if len(parser.GetSchemas()) != 0 {
+		t.Errorf("expected no schemas before reading, got %d", len(parser.GetSchemas()))
+	}
+
+	// Filter names cannot be validated before the FMT messages are seen
+	if err := parser.SetFilter("GPS"); err != nil {
+		t.Fatalf("failed to set filter: %v", err)
+	}
+
+	msg, err := parser.ReadMessage()
+	if err != nil {
+		t.Fatalf("error reading message: %v", err)
+	}
+	if msg.Name != "GPS" {
+		t.Errorf("expected GPS, got %s", msg.Name)
+	}
+	if msg.LineNo != 17 {
+		t.Errorf("expected LineNo 17, got %d", msg.LineNo)
+	}
+
+	// FMTU messages were absorbed even though they were filtered out
+	value, unit, err := msg.GetScaled("Alt")
+	if err != nil {
+		t.Fatalf("error getting scaled value: %v", err)
+	}
+	if unit != "m" || value.(float64) != 275.3 {
+		t.Errorf("got %v %s, want 275.3 m", value, unit)
+	}
+	if schema := parser.GetSchemas()[testIMUType]; schema == nil || schema.Units != "s#EEE" {
+		t.Errorf("expected IMU schema with units, got %+v", schema)
+	}
+
+	if err := parser.Rewind(); err != ErrNotSeekable {
+		t.Errorf("expected ErrNotSeekable, got %v", err)
+	}
+	if _, err := parser.GetSlice(0, 10, SliceByLineNo); err != ErrNotSeekable {
+		t.Errorf("expected ErrNotSeekable, got %v", err)
+	}
+}
+
+func TestStreamParserResync(t *testing.T) {
+	var l testLog
+	l.writePreamble()
+	l.writeIMU(1000)
+	l.Write([]byte{0x00, HEAD1, 0x01, 0x02})
+	l.writeIMU(2000)
+
+	parser := NewStreamParser(streamOnly{&l})
+	if err := parser.SetFilter("IMU"); err != nil {
+		t.Fatalf("failed to set filter: %v", err)
+	}
+
+	var times []int64
+	for {
+		msg, err := parser.ReadMessage()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("error reading message: %v", err)
+		}
+		times = append(times, msg.TimeUS)
+	}
+	if !reflect.DeepEqual(times, []int64{1000, 2000}) {
+		t.Errorf("got TimeUS %v, want [1000 2000]", times)
+	}
+}
+
+func TestSinglePass(t *testing.T) {
+	data := sampleLog(100)
+	parser, err := NewParserFromReader(bytes.NewReader(data), WithPrescan(false))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	if len(parser.GetSchemas()) != 0 {
+		t.Errorf("expected no schemas before reading, got %d", len(parser.GetSchemas()))
+	}
+
+	// Names are not validated without a pre-scan
+	if err := parser.SetFilter("IMU", "NOTYET"); err != nil {
+		t.Fatalf("failed to set filter: %v", err)
+	}
+	counts := countMessages(t, parser)
+	if !reflect.DeepEqual(counts, map[string]int{"IMU": 100}) {
+		t.Errorf("got %v, want 100 IMU messages", counts)
+	}
+	if len(parser.GetSchemas()) != 4 {
+		t.Errorf("expected 4 schemas after reading, got %d", len(parser.GetSchemas()))
+	}
+
+	// LineNo matches a pre-scanned parser
+	prescanned, err := NewParserFromReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	for _, p := range []*Parser{parser, prescanned} {
+		if err := p.SetFilter("GPS"); err != nil {
+			t.Fatalf("failed to set filter: %v", err)
+		}
+	}
+	for range 5 {
+		a, errA := parser.ReadMessage()
+		b, errB := prescanned.ReadMessage()
+		if errA != nil || errB != nil {
+			t.Fatalf("error reading messages: %v, %v", errA, errB)
+		}
+		if a.LineNo != b.LineNo || a.TimeUS != b.TimeUS {
+			t.Errorf("single pass got LineNo %d TimeUS %d, pre-scan got %d %d",
+				a.LineNo, a.TimeUS, b.LineNo, b.TimeUS)
+		}
+	}
+}
+
+func TestUnitAndMultMessages(t *testing.T) {
+	unitSchema := &Schema{Type: 140, Length: 76, Name: "UNIT", Format: "QbZ", Columns: "TimeUS,Id,Label"}
+	multSchema := &Schema{Type: 141, Length: 20, Name: "MULT", Format: "Qbd", Columns: "TimeUS,Id,Mult"}
+
+	var l testLog
+	l.writeFMT(fmtSchema)
+	l.writeFMT(unitSchema)
+	l.writeFMT(multSchema)
+	l.writeMessage(140, uint64(0), int8('m'))
+	l.writeString("m", 64)
+	l.writeMessage(141, uint64(0), int8('F'), float64(1e-6))
+
+	for _, prescan := range []bool{true, false} {
+		parser, err := NewParserFromReader(bytes.NewReader(l.Bytes()), WithPrescan(prescan))
+		if err != nil {
+			t.Fatalf("failed to create parser: %v", err)
+		}
+		countMessages(t, parser)
+
+		if got := parser.Units()['m']; got != "m" {
+			t.Errorf("prescan=%v: unit 'm' = %q, want \"m\"", prescan, got)
+		}
+		if got := parser.Multipliers()['F']; got != 1e-6 {
+			t.Errorf("prescan=%v: multiplier 'F' = %v, want 1e-6", prescan, got)
+		}
+	}
+}
+
+// corruptLog returns a log with n IMU messages each followed by a few junk
+// bytes, so every message after the first is found by resyncing.
+func corruptLog(n int) []byte {
+	var l testLog
+	l.writePreamble()
+	for i := range n {
+		l.writeIMU(uint64(1000 * (i + 1)))
+		l.Write([]byte{0x00, HEAD1, 0x01, 0x02, 0x03})
+	}
+	return l.Bytes()
+}
+
+func TestResyncAcrossBufferBoundaries(t *testing.T) {
+	// Junk follows every message, so resyncs can't be confirmed by the
+	// header after the candidate
+	data := corruptLog(1000)
+	parsers := map[string]*Parser{
+		"stream": NewStreamParser(streamOnly{bytes.NewReader(data)}, WithBufferSize(minBufferSize), WithResyncDepth(1)),
+	}
+	seekable, err := NewParserFromReader(bytes.NewReader(data), WithBufferSize(minBufferSize), WithResyncDepth(1))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	parsers["seekable"] = seekable
+
+	for name, parser := range parsers {
+		if err := parser.SetFilter("IMU"); err != nil {
+			t.Fatalf("%s: failed to set filter: %v", name, err)
+		}
+		counts := countMessages(t, parser)
+		if counts["IMU"] != 1000 {
+			t.Errorf("%s: expected 1000 IMU messages, got %d", name, counts["IMU"])
+		}
+	}
+}
+
+func TestResyncValidatesCandidates(t *testing.T) {
+	// Corruption leaves a fake GPS header whose length would swallow the
+	// next real IMU header
+	var l testLog
+	l.writePreamble()
+	l.writeIMU(1000)
+	l.Write([]byte{0x00, HEAD1, HEAD2, testGPSType, 0x01, 0x02, 0x03, 0x04, 0x05})
+	l.writeIMU(2000)
+	l.writeIMU(3000)
+	l.Write([]byte{0x00})
+	l.writeIMU(4000) // Confirmed by reaching the end of the log
+
+	tests := []struct {
+		depth int
+		want  []string
+	}{
+		{1, []string{"IMU:1000", "GPS", "IMU:3000", "IMU:4000"}},
+		{2, []string{"IMU:1000", "IMU:2000", "IMU:3000", "IMU:4000"}},
+		// The second junk byte breaks the chain from IMU:2000
+		{4, []string{"IMU:1000", "IMU:4000"}},
+	}
+	for _, tt := range tests {
+		parser, err := NewParserFromBytes(l.Bytes(), WithResyncDepth(tt.depth))
+		if err != nil {
+			t.Fatalf("failed to create parser: %v", err)
+		}
+		if err := parser.SetFilter("IMU", "GPS"); err != nil {
+			t.Fatalf("failed to set filter: %v", err)
+		}
+
+		var got []string
+		for msg, err := range parser.All() {
+			if err != nil {
+				t.Fatalf("depth %d: unexpected error: %v", tt.depth, err)
+			}
+			if msg.Name == "GPS" {
+				got = append(got, msg.Name) // Garbage fields
+				continue
+			}
+			got = append(got, fmt.Sprintf("%s:%d", msg.Name, msg.TimeUS))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("depth %d: got %v, want %v", tt.depth, got, tt.want)
+		}
+	}
+}
+
+func TestResyncChainBeyondBuffer(t *testing.T) {
+	// A fake BIG header in junk is followed by a real BIG message, and the
+	// third header of its chain lies past the end of a minimal read buffer
+	big := &Schema{Type: 132, Length: maxMessageLength, Name: "BIG", Format: "QZZZNNNi",
+		Columns: "TimeUS,A,B,C,D,E,F,G"}
+	body := make([]byte, maxMessageLength-HeaderSize)
+
+	var l testLog
+	l.writePreamble()
+	l.writeFMT(big)
+	l.writeIMU(1000)
+	l.Write([]byte{0x00})
+	l.writeMessage(big.Type, body) // Fake
+	l.writeMessage(big.Type, body)
+	l.Write([]byte{0x00})
+	l.writeIMU(2000)
+	l.writeIMU(3000)
+
+	parser, err := NewParserFromReader(bytes.NewReader(l.Bytes()), WithBufferSize(minBufferSize), WithResyncDepth(3))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	counts := countMessages(t, parser)
+	if counts["BIG"] != 0 || counts["IMU"] != 3 {
+		t.Errorf("expected the BIG chain to be rejected, got %v", counts)
+	}
+}
+
+func BenchmarkReadMessage(b *testing.B) {
+	data := sampleLog(100000)
+	b.SetBytes(int64(len(data)))
+	for b.Loop() {
+		parser, err := NewParserFromReader(bytes.NewReader(data))
+		if err != nil {
+			b.Fatalf("failed to create parser: %v", err)
+		}
+		for {
+			if _, err := parser.ReadMessage(); err != nil {
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkReadMessageFiltered(b *testing.B) {
+	data := sampleLog(100000)
+	b.SetBytes(int64(len(data)))
+	for b.Loop() {
+		parser, err := NewParserFromReader(bytes.NewReader(data))
+		if err != nil {
+			b.Fatalf("failed to create parser: %v", err)
+		}
+		if err := parser.SetFilter("GPS"); err != nil {
+			b.Fatalf("failed to set filter: %v", err)
+		}
+		for {
+			if _, err := parser.ReadMessage(); err != nil {
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkResync(b *testing.B) {
+	data := corruptLog(100000)
+	b.SetBytes(int64(len(data)))
+	for b.Loop() {
+		parser, err := NewParserFromReader(bytes.NewReader(data))
+		if err != nil {
+			b.Fatalf("failed to create parser: %v", err)
+		}
+		for {
+			if _, err := parser.ReadMessage(); err != nil {
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkReadMessageFile(b *testing.B) {
+	data := sampleLog(100000)
+	filename := filepath.Join(b.TempDir(), "bench.bin")
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		b.Fatalf("failed to write log: %v", err)
+	}
+	b.SetBytes(int64(len(data)))
+	for b.Loop() {
+		parser, err := NewParser(filename)
+		if err != nil {
+			b.Fatalf("failed to create parser: %v", err)
+		}
+		for {
+			if _, err := parser.ReadMessage(); err != nil {
+				break
+			}
+		}
+		parser.Close()
+	}
+}
+
+func BenchmarkBufferSize(b *testing.B) {
+	data := sampleLog(100000)
+	filename := filepath.Join(b.TempDir(), "bench.bin")
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		b.Fatalf("failed to write log: %v", err)
+	}
+	for _, size := range []int{4 << 10, 64 << 10, 256 << 10} {
+		b.Run(fmt.Sprintf("%dKB", size>>10), func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for b.Loop() {
+				parser, err := NewParserWithOptions(filename, WithBufferSize(size))
+				if err != nil {
+					b.Fatalf("failed to create parser: %v", err)
+				}
+				for {
+					if _, err := parser.ReadMessage(); err != nil {
+						break
+					}
+				}
+				parser.Close()
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package dataflash
+
+import (
+	"context"
+	"math"
+)
+
+const (
+	progressInterval = 1 << 20       // Bytes read between progress reports
+	progressDone     = math.MaxInt64 // Final report of the current pass was sent
+	contextInterval  = 1024          // Messages read between context checks
+)
+
+// ProgressStage identifies the pass a parser is making over a log.
+type ProgressStage string
+
+const (
+	StageSchemas   ProgressStage = "schemas"   // Pre-scan collecting schemas
+	StageIndex     ProgressStage = "index"     // BuildIndex
+	StageSegments  ProgressStage = "segments"  // Segments and ArmCycles
+	StageTrim      ProgressStage = "trim"      // Trim, WriteSegment and Split
+	StageAnonymize ProgressStage = "anonymize" // Anonymize
+	StageMerge     ProgressStage = "merge"     // NewMerger reading GPS time
+	StageMessages  ProgressStage = "messages"  // Reading messages
+)
+
+// Progress describes how far a parser has read through a log.
+type Progress struct {
+	Stage      ProgressStage
+	BytesRead  int64 // Offset reached in the log
+	TotalBytes int64 // Size of the log, -1 if unknown (stream parsers)
+	Messages   int64 // Messages read so far in this pass, including filtered ones
+}
+
+// reportProgress calls the progress callback if another progressInterval
+// bytes have been read since the last report, or if final is set and the
+// final report of this pass has not been sent yet.
+func (p *Parser) reportProgress(stage ProgressStage, messages int64, final bool) {
+	if p.opts.progress == nil || p.progressAt == progressDone {
+		return
+	}
+	pos := p.r.pos()
+	if !final && pos < p.progressAt {
+		return
+	}
+
+	p.opts.progress(Progress{
+		Stage:      stage,
+		BytesRead:  pos,
+		TotalBytes: p.r.size(),
+		Messages:   messages,
+	})
+
+	if final {
+		p.progressAt = progressDone
+	} else {
+		p.progressAt = pos + progressInterval
+	}
+}
+
+// checkContext returns ctx.Err() on every contextInterval-th message.
+func checkContext(ctx context.Context, messages int64) error {
+	if messages%contextInterval != 0 {
+		return nil
+	}
+	return ctx.Err()
+}
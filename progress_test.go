@@ -0,0 +1,81 @@
+package dataflash
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestProgress(t *testing.T) {
+	data := sampleLog(100000)
+	filename := writeTempLog(t, data)
+
+	var reports []Progress
+	parser, err := NewParserWithOptions(filename, WithProgress(func(p Progress) {
+		reports = append(reports, p)
+	}))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	defer parser.Close()
+	for _, err := range parser.All() {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	final := map[ProgressStage]Progress{}
+	var last Progress
+	for _, report := range reports {
+		if report.TotalBytes != int64(len(data)) {
+			t.Fatalf("TotalBytes = %d, want %d", report.TotalBytes, len(data))
+		}
+		if report.Stage == last.Stage && report.BytesRead < last.BytesRead {
+			t.Errorf("BytesRead went back from %d to %d", last.BytesRead, report.BytesRead)
+		}
+		final[report.Stage] = report
+		last = report
+	}
+
+	if len(reports) < 4 {
+		t.Errorf("expected several reports, got %d", len(reports))
+	}
+	for _, stage := range []ProgressStage{StageSchemas, StageMessages} {
+		report, ok := final[stage]
+		if !ok {
+			t.Fatalf("no %s progress reported", stage)
+		}
+		if report.BytesRead != int64(len(data)) || report.Messages != 6+100000+10000 {
+			t.Errorf("final %s report = %+v", stage, report)
+		}
+	}
+}
+
+func TestNewParserContextCanceled(t *testing.T) {
+	filename := writeTempLog(t, sampleLog(10000))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewParserContext(ctx, filename); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestGetSliceContextCanceled(t *testing.T) {
+	parser, err := NewParserFromBytes(sampleLog(10000))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := parser.GetSliceContext(ctx, 5000, 5010, SliceByLineNo); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if err := parser.BuildIndexContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if parser.Index() != nil {
+		t.Error("expected no index after cancellation")
+	}
+}
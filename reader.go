@@ -0,0 +1,298 @@
+package dataflash
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	defaultBufferSize = 64 * 1024 // Default read buffer size
+	minBufferSize     = 512       // Large enough to hold any message (max 255 bytes)
+	maxMessageLength  = 255       // Largest message, as FMT stores the length in a byte
+)
+
+// magic is the two-byte sequence that starts every message header.
+var magic = []byte{HEAD1, HEAD2}
+
+// logReader is the input a Parser reads messages from.
+// Slices returned by peek and next are only valid until the next call.
+type logReader interface {
+	// peek returns the next n bytes without advancing the reader.
+	// It returns io.EOF if no bytes are left and io.ErrUnexpectedEOF if
+	// fewer than n bytes are left.
+	peek(n int) ([]byte, error)
+	// next returns the next n bytes and advances the reader past them.
+	// A truncated read consumes the remaining bytes.
+	next(n int) ([]byte, error)
+	// discard skips n bytes.
+	discard(n int64) error
+	// seek moves the read position to the absolute offset.
+	seek(offset int64) error
+	// seekable reports whether seek is supported.
+	seekable() bool
+	// pos returns the offset of the next unread byte.
+	pos() int64
+	// size returns the total size of the input, or -1 if it is unknown.
+	size() int64
+	// syncToHeader advances to the next HEAD1 HEAD2 pair, leaving the magic
+	// bytes unread. It returns io.EOF if there is none.
+	syncToHeader() error
+	// clone returns a reader over the same input with its own position, or
+	// nil if the input can't be shared.
+	clone() logReader
+}
+
+// bufferedReader wraps the parser input with a read buffer and tracks the
+// byte offset of the read position. Slices returned by next point into the
+// buffer and are only valid until the next call, so message bodies are
+// decoded without being copied.
+type bufferedReader struct {
+	src    io.Reader
+	seeker io.Seeker // nil for forward-only streams
+	buf    *bufio.Reader
+	offset int64 // Offset of the next unread byte in src
+	total  int64 // Size of src, -1 if unknown
+}
+
+// newBufferedReader returns a reader buffering src with size bytes.
+// seeker, if not nil, must reposition src.
+func newBufferedReader(src io.Reader, seeker io.Seeker, size int) *bufferedReader {
+	if size < minBufferSize {
+		size = minBufferSize
+	}
+	r := &bufferedReader{
+		src:    src,
+		seeker: seeker,
+		buf:    bufio.NewReaderSize(src, size),
+		total:  -1,
+	}
+	if seeker != nil {
+		r.total = seekSize(seeker)
+	}
+	return r
+}
+
+// seekSize returns the size of s without moving its position, or -1.
+func seekSize(s io.Seeker) int64 {
+	current, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return -1
+	}
+	end, err := s.Seek(0, io.SeekEnd)
+	if err != nil {
+		return -1
+	}
+	if _, err := s.Seek(current, io.SeekStart); err != nil {
+		return -1
+	}
+	return end
+}
+
+func (r *bufferedReader) peek(n int) ([]byte, error) {
+	b, err := r.buf.Peek(n)
+	if len(b) == n {
+		return b, nil
+	}
+	if err == io.EOF && len(b) > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return nil, err
+}
+
+func (r *bufferedReader) next(n int) ([]byte, error) {
+	b, err := r.peek(n)
+	if err != nil {
+		if err == io.ErrUnexpectedEOF {
+			r.offset += int64(r.buf.Buffered())
+			r.buf.Discard(r.buf.Buffered())
+		}
+		return nil, err
+	}
+	r.buf.Discard(n)
+	r.offset += int64(n)
+	return b, nil
+}
+
+// discard skips n bytes, seeking past data that is not buffered yet when
+// the source supports it.
+func (r *bufferedReader) discard(n int64) error {
+	if buffered := int64(r.buf.Buffered()); n > buffered && r.seeker != nil {
+		// Seeking past the end succeeds, so check against the size
+		if r.total >= 0 && r.offset+n > r.total {
+			atEnd := r.offset >= r.total
+			if err := r.seek(r.total); err != nil {
+				return err
+			}
+			if atEnd {
+				return io.EOF
+			}
+			return io.ErrUnexpectedEOF
+		}
+		return r.seek(r.offset + n)
+	}
+	discarded, err := r.buf.Discard(int(n))
+	r.offset += int64(discarded)
+	if err == io.EOF && discarded > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// seek moves the read position to the absolute offset and drops the buffer.
+func (r *bufferedReader) seek(offset int64) error {
+	if r.seeker == nil {
+		return ErrNotSeekable
+	}
+	if _, err := r.seeker.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	r.buf.Reset(r.src)
+	r.offset = offset
+	return nil
+}
+
+func (r *bufferedReader) seekable() bool {
+	return r.seeker != nil
+}
+
+func (r *bufferedReader) pos() int64 {
+	return r.offset
+}
+
+func (r *bufferedReader) size() int64 {
+	return r.total
+}
+
+// syncToHeader searches the buffer rather than reading byte by byte.
+func (r *bufferedReader) syncToHeader() error {
+	for {
+		if r.buf.Buffered() < len(magic) {
+			if _, err := r.buf.Peek(len(magic)); err != nil {
+				// Less than a header is left
+				r.offset += int64(r.buf.Buffered())
+				r.buf.Discard(r.buf.Buffered())
+				return io.EOF
+			}
+		}
+
+		window, _ := r.buf.Peek(r.buf.Buffered())
+		if i := bytes.Index(window, magic); i >= 0 {
+			r.buf.Discard(i)
+			r.offset += int64(i)
+			return nil
+		}
+
+		// Keep the last byte in case it is the first half of a header
+		n := len(window) - 1
+		r.buf.Discard(n)
+		r.offset += int64(n)
+	}
+}
+
+// clone shares inputs that support io.ReaderAt, such as files.
+func (r *bufferedReader) clone() logReader {
+	src, ok := r.src.(io.ReaderAt)
+	if !ok || r.total < 0 {
+		return nil
+	}
+	section := io.NewSectionReader(src, 0, r.total)
+	return newBufferedReader(section, section, r.buf.Size())
+}
+
+// memoryReader reads from a byte slice, such as a memory-mapped file.
+// Slices returned by peek and next point directly into the data.
+type memoryReader struct {
+	data   []byte
+	offset int
+	closed bool // Set once the data is unmapped; reads return os.ErrClosed
+}
+
+// close drops the data, so that reads after it is unmapped fail instead of
+// touching the unmapped region.
+func (r *memoryReader) close() {
+	r.data = nil
+	r.offset = 0
+	r.closed = true
+}
+
+func (r *memoryReader) peek(n int) ([]byte, error) {
+	if r.closed {
+		return nil, os.ErrClosed
+	}
+	if r.offset >= len(r.data) {
+		return nil, io.EOF
+	}
+	if r.offset+n > len(r.data) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return r.data[r.offset : r.offset+n], nil
+}
+
+func (r *memoryReader) next(n int) ([]byte, error) {
+	b, err := r.peek(n)
+	if err != nil {
+		r.offset = len(r.data)
+		return nil, err
+	}
+	r.offset += n
+	return b, nil
+}
+
+func (r *memoryReader) discard(n int64) error {
+	if r.closed {
+		return os.ErrClosed
+	}
+	if n > int64(len(r.data)-r.offset) {
+		atEnd := r.offset >= len(r.data)
+		r.offset = len(r.data)
+		if atEnd {
+			return io.EOF
+		}
+		return io.ErrUnexpectedEOF
+	}
+	r.offset += int(n)
+	return nil
+}
+
+func (r *memoryReader) seek(offset int64) error {
+	if r.closed {
+		return os.ErrClosed
+	}
+	if offset < 0 || offset > int64(len(r.data)) {
+		return fmt.Errorf("seek offset %d out of range [0, %d]", offset, len(r.data))
+	}
+	r.offset = int(offset)
+	return nil
+}
+
+func (r *memoryReader) seekable() bool {
+	return true
+}
+
+func (r *memoryReader) pos() int64 {
+	return int64(r.offset)
+}
+
+func (r *memoryReader) size() int64 {
+	return int64(len(r.data))
+}
+
+func (r *memoryReader) syncToHeader() error {
+	if r.closed {
+		return os.ErrClosed
+	}
+	i := bytes.Index(r.data[r.offset:], magic)
+	if i < 0 {
+		r.offset = len(r.data)
+		return io.EOF
+	}
+	r.offset += i
+	return nil
+}
+
+func (r *memoryReader) clone() logReader {
+	return &memoryReader{data: r.data}
+}
@@ -0,0 +1,224 @@
+package dataflash
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldDef describes one field of a schema, compiled once from its FMT and
+// FMTU messages.
+type FieldDef struct {
+	Name   string       // Column name
+	Format rune         // Format character
+	Offset int          // Byte offset within the message body
+	Size   int          // Size in bytes
+	Unit   rune         // Unit identifier from FMTU ('-' if none)
+	Mult   rune         // Multiplier identifier from FMTU ('-' if none)
+	Kind   reflect.Kind // Kind of the value in Message.Fields ([]int16 for Slice)
+}
+
+// SchemaError reports a schema whose layout cannot be decoded reliably, such
+// as a FMT message that was corrupted in the log.
+type SchemaError struct {
+	Type   uint8  // Message type ID
+	Name   string // Message name
+	Reason string // What is wrong with the schema
+	Err    error  // Underlying error such as ErrUnknownFormatChar, or nil
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("invalid schema %s (type %d): %s", e.Name, e.Type, e.Reason)
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// compile builds the field table from Format, Columns, Units and Mults the
+// first time it is called. Later calls, possibly from several goroutines,
+// leave the table as it is.
+func (s *Schema) compile() {
+	s.once.Do(s.build)
+}
+
+// build builds the field table for compile.
+func (s *Schema) build() {
+	columns := strings.Split(s.Columns, ",")
+	s.fields = make([]FieldDef, 0, len(s.Format))
+	s.index = make(map[string]int, len(s.Format))
+	s.timeUS = -1
+	s.err = nil
+
+	offset := 0
+	for i, dataType := range s.Format {
+		// Stop at null terminator or when we run out of columns
+		if dataType == 0 || i >= len(columns) {
+			break
+		}
+
+		field := FieldDef{
+			Name:   columns[i],
+			Format: dataType,
+			Offset: offset,
+			Size:   formatSizes[dataType],
+			Unit:   '-',
+			Mult:   '-',
+			Kind:   formatKind(dataType),
+		}
+		if field.Kind == reflect.Invalid && s.err == nil {
+			s.err = s.invalid(ErrUnknownFormatChar, "%v %q", ErrUnknownFormatChar, dataType)
+		}
+		if i < len(s.Units) {
+			field.Unit = rune(s.Units[i])
+		}
+		if i < len(s.Mults) {
+			field.Mult = rune(s.Mults[i])
+		}
+		if field.Name == "TimeUS" && (dataType == 'Q' || dataType == 'q') &&
+			offset+8 <= int(s.Length)-HeaderSize {
+			s.timeUS = offset
+		}
+
+		if _, ok := s.index[field.Name]; !ok {
+			s.index[field.Name] = len(s.fields)
+		}
+		s.fields = append(s.fields, field)
+		offset += field.Size
+	}
+	s.size = offset
+}
+
+// clone returns an uncompiled schema with the same definition as s.
+func (s *Schema) clone() *Schema {
+	return &Schema{
+		Type:    s.Type,
+		Length:  s.Length,
+		Name:    s.Name,
+		Format:  s.Format,
+		Columns: s.Columns,
+		Units:   s.Units,
+		Mults:   s.Mults,
+	}
+}
+
+// setUnits attaches the unit and multiplier identifiers of a FMTU message
+// to the compiled fields.
+func (s *Schema) setUnits(units, mults string) {
+	s.Units = units
+	s.Mults = mults
+	for i := range s.Fields() {
+		s.fields[i].Unit, s.fields[i].Mult = '-', '-'
+		if i < len(units) {
+			s.fields[i].Unit = rune(units[i])
+		}
+		if i < len(mults) {
+			s.fields[i].Mult = rune(mults[i])
+		}
+	}
+}
+
+// Validate checks that the schema describes a layout that can be decoded:
+// every format character is known, there is one distinct column name per
+// format character and the field sizes add up to Length. Schemas read from
+// a log are validated as their FMT message is read, and messages of an
+// invalid schema fail to decode with the returned *SchemaError.
+func (s *Schema) Validate() error {
+	fields := s.Fields()
+	if s.err != nil {
+		return s.err
+	}
+
+	if int(s.Length) < HeaderSize {
+		return s.invalid(nil, "length %d is shorter than the %d-byte header", s.Length, HeaderSize)
+	}
+
+	formatLen := strings.IndexByte(s.Format, 0)
+	if formatLen < 0 {
+		formatLen = len(s.Format)
+	}
+	columnCount := 0
+	if s.Columns != "" {
+		columnCount = strings.Count(s.Columns, ",") + 1
+	}
+	if columnCount != formatLen {
+		return s.invalid(nil, "%d columns for %d format characters", columnCount, formatLen)
+	}
+
+	for i, field := range fields {
+		if s.index[field.Name] != i {
+			return s.invalid(nil, "duplicate column %q", field.Name)
+		}
+	}
+
+	if bodySize := int(s.Length) - HeaderSize; s.size != bodySize {
+		return s.invalid(nil, "format needs %d bytes but length %d leaves %d", s.size, s.Length, bodySize)
+	}
+	return nil
+}
+
+// invalid returns a *SchemaError for s.
+func (s *Schema) invalid(err error, format string, args ...any) *SchemaError {
+	return &SchemaError{
+		Type:   s.Type,
+		Name:   s.Name,
+		Reason: fmt.Sprintf(format, args...),
+		Err:    err,
+	}
+}
+
+// Fields returns the compiled field table of the schema. The slice is shared
+// and must not be modified.
+func (s *Schema) Fields() []FieldDef {
+	s.compile()
+	return s.fields
+}
+
+// ColumnIndex returns the index of the named column in Fields, for use with
+// FieldReader, or -1 if the schema has no such column.
+func (s *Schema) ColumnIndex(name string) int {
+	s.compile()
+	if i, ok := s.index[name]; ok {
+		return i
+	}
+	return -1
+}
+
+// timeUSOffset returns the offset of the TimeUS field within a message body,
+// or -1 if the schema has no 64-bit TimeUS field.
+func (s *Schema) timeUSOffset() int {
+	s.compile()
+	return s.timeUS
+}
+
+// formatKind returns the kind of the value DecodeMessageBody produces for a
+// format character, or reflect.Invalid for unknown characters.
+func formatKind(dataType rune) reflect.Kind {
+	switch dataType {
+	case 'B', 'M':
+		return reflect.Uint8
+	case 'H':
+		return reflect.Uint16
+	case 'I':
+		return reflect.Uint32
+	case 'Q':
+		return reflect.Uint64
+	case 'b':
+		return reflect.Int8
+	case 'h':
+		return reflect.Int16
+	case 'i':
+		return reflect.Int32
+	case 'q':
+		return reflect.Int64
+	case 'f', 'g':
+		return reflect.Float32
+	case 'd', 'c', 'C', 'e', 'E', 'L':
+		return reflect.Float64
+	case 'n', 'N', 'Z':
+		return reflect.String
+	case 'a':
+		return reflect.Slice
+	}
+	return reflect.Invalid
+}
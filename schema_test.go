@@ -0,0 +1,167 @@
+package dataflash
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSchemaFields(t *testing.T) {
+	parser, err := NewParserFromBytes(sampleLog(10))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	gps := parser.GetSchemas()[testGPSType]
+	expected := []FieldDef{
+		{Name: "TimeUS", Format: 'Q', Offset: 0, Size: 8, Unit: 's', Mult: 'F', Kind: reflect.Uint64},
+		{Name: "Status", Format: 'B', Offset: 8, Size: 1, Unit: '-', Mult: '-', Kind: reflect.Uint8},
+		{Name: "Lat", Format: 'L', Offset: 9, Size: 4, Unit: 'D', Mult: 'G', Kind: reflect.Float64},
+		{Name: "Lng", Format: 'L', Offset: 13, Size: 4, Unit: 'U', Mult: 'G', Kind: reflect.Float64},
+		{Name: "Alt", Format: 'e', Offset: 17, Size: 4, Unit: 'm', Mult: 'B', Kind: reflect.Float64},
+	}
+	if !reflect.DeepEqual(gps.Fields(), expected) {
+		t.Errorf("got %+v, want %+v", gps.Fields(), expected)
+	}
+	if i := gps.ColumnIndex("Lng"); i != 3 {
+		t.Errorf("expected Lng at index 3, got %d", i)
+	}
+	if i := gps.ColumnIndex("Missing"); i != -1 {
+		t.Errorf("expected -1 for a missing column, got %d", i)
+	}
+	if offset := gps.timeUSOffset(); offset != 0 {
+		t.Errorf("expected TimeUS at offset 0, got %d", offset)
+	}
+}
+
+func TestSchemaFieldsUncompiled(t *testing.T) {
+	// Schemas built by hand are compiled on first use
+	schema := &Schema{
+		Format:  "BhZ",
+		Columns: "A,B,C",
+		Length:  70,
+		Units:   "-m-",
+	}
+
+	fields := schema.Fields()
+	if len(fields) != 3 {
+		t.Fatalf("expected 3 fields, got %d", len(fields))
+	}
+	if fields[1].Offset != 1 || fields[1].Unit != 'm' || fields[1].Kind != reflect.Int16 {
+		t.Errorf("unexpected field B: %+v", fields[1])
+	}
+	if fields[2].Offset != 3 || fields[2].Size != 64 || fields[2].Mult != '-' {
+		t.Errorf("unexpected field C: %+v", fields[2])
+	}
+	if offset := schema.timeUSOffset(); offset != -1 {
+		t.Errorf("expected no TimeUS, got offset %d", offset)
+	}
+}
+
+func TestSchemaFieldsConcurrent(t *testing.T) {
+	// A hand-built schema can be compiled from several goroutines at once
+	schema := &Schema{Length: 12, Format: "QB", Columns: "TimeUS,I"}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			if len(schema.Fields()) != 2 || schema.ColumnIndex("I") != 1 || schema.timeUSOffset() != 0 {
+				t.Errorf("unexpected fields %+v", schema.Fields())
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestSchemaValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *Schema
+		reason string
+	}{
+		{"valid", &Schema{Length: 12, Format: "QB", Columns: "TimeUS,I"}, ""},
+		{"unknown char", &Schema{Length: 12, Format: "QX", Columns: "TimeUS,I"}, "unknown format character 'X'"},
+		{"short length", &Schema{Length: 2, Format: "", Columns: ""}, "length 2 is shorter than the 3-byte header"},
+		{"too few columns", &Schema{Length: 12, Format: "QB", Columns: "TimeUS"}, "1 columns for 2 format characters"},
+		{"too many columns", &Schema{Length: 12, Format: "QB", Columns: "TimeUS,I,J"}, "3 columns for 2 format characters"},
+		{"duplicate column", &Schema{Length: 13, Format: "QBB", Columns: "TimeUS,I,I"}, `duplicate column "I"`},
+		{"length too short", &Schema{Length: 11, Format: "QB", Columns: "TimeUS,I"}, "format needs 9 bytes but length 11 leaves 8"},
+		{"length too long", &Schema{Length: 20, Format: "QB", Columns: "TimeUS,I"}, "format needs 9 bytes but length 20 leaves 17"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := tt.schema
+			schema.Type, schema.Name = 42, "TEST"
+			err := schema.Validate()
+			if tt.reason == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("expected *SchemaError, got %v", err)
+			}
+			if schemaErr.Type != 42 || schemaErr.Name != "TEST" || schemaErr.Reason != tt.reason {
+				t.Errorf("got %+v, want reason %q", schemaErr, tt.reason)
+			}
+		})
+	}
+}
+
+func TestInvalidSchemaInLog(t *testing.T) {
+	// A FMT whose length disagrees with its format must not take down the
+	// rest of the log
+	bad := &Schema{Type: 140, Length: 10, Name: "BAD", Format: "QI", Columns: "TimeUS,V"}
+
+	var l testLog
+	l.writePreamble()
+	l.writeFMT(bad)
+	l.writeIMU(1000)
+	l.writeMessage(bad.Type, [7]byte{})
+	l.writeIMU(2000)
+
+	parser, err := NewParserFromBytes(l.Bytes())
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if err := parser.GetSchemas()[bad.Type].Validate(); err == nil {
+		t.Error("expected BAD schema to be invalid")
+	}
+
+	var names []string
+	var schemaErr *SchemaError
+	for {
+		msg, err := parser.ReadMessage()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			names = append(names, "error")
+			continue
+		}
+		names = append(names, msg.Name)
+	}
+
+	expected := []string{"FMT", "FMT", "FMT", "FMT", "FMTU", "FMTU", "FMT", "IMU", "error", "IMU"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("got %v, want %v", names, expected)
+	}
+	if schemaErr == nil || schemaErr.Name != "BAD" {
+		t.Errorf("expected error for BAD, got %v", schemaErr)
+	}
+}
+
+func TestDecodeShortBody(t *testing.T) {
+	schema := &Schema{Name: "SHRT", Length: 12, Format: "QB", Columns: "TimeUS,I"}
+	if _, err := DecodeMessageBody(make([]byte, 5), schema); err == nil {
+		t.Error("expected an error for a short body")
+	}
+}
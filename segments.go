@@ -0,0 +1,236 @@
+package dataflash
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// bootRegressionUS is how far TimeUS must fall back before a message is
+// taken to start a new boot. Messages from different sensors are not
+// strictly ordered, so small regressions are normal.
+const bootRegressionUS = 1_000_000
+
+// Segment is a contiguous part of a log, such as the messages written
+// during one boot or one arm cycle.
+type Segment struct {
+	Start       int64 // Offset of the first message
+	End         int64 // Offset just past the last message
+	FirstLineNo int64 // LineNo of the first message
+	Messages    int64 // Number of messages
+	StartUS     int64 // Lowest TimeUS in the segment
+	EndUS       int64 // Highest TimeUS in the segment
+}
+
+// Segments reads the whole log once and splits it into boots, so that logs
+// spanning reboots or concatenated from several files can be handled one
+// boot at a time. A boot starts with the FMT message describing FMT itself,
+// which every boot writes first, or where TimeUS falls back by more than a
+// second. The result is cached and the parser is rewound when done.
+// Returns ErrNotSeekable for stream parsers.
+func (p *Parser) Segments() ([]Segment, error) {
+	return p.SegmentsContext(context.Background())
+}
+
+// SegmentsContext is like Segments but stops with ctx.Err() once ctx is done.
+func (p *Parser) SegmentsContext(ctx context.Context) ([]Segment, error) {
+	if p.segments != nil {
+		return p.segments, nil
+	}
+	if err := p.Rewind(); err != nil {
+		return nil, err
+	}
+
+	var segments []Segment
+	var current *Segment
+	for {
+		if err := checkContext(ctx, p.lineNo); err != nil {
+			return nil, err
+		}
+
+		schema, body, err := p.skipMessage()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		p.reportProgress(StageSegments, p.lineNo, false)
+
+		timeUS, hasTime := int64(0), false
+		if offset := schema.timeUSOffset(); offset >= 0 {
+			timeUS, hasTime = int64(binary.LittleEndian.Uint64(body[offset:])), true
+		}
+
+		boot := current == nil
+		if current != nil {
+			// FMT for FMT starts every boot's preamble; late FMTs for other
+			// types don't start a new boot
+			preamble := schema.Name == "FMT" && len(body) > 0 && body[0] == FMTType
+			regressed := hasTime && current.EndUS != math.MinInt64 &&
+				timeUS < current.EndUS-bootRegressionUS
+			boot = preamble || regressed
+		}
+		if boot {
+			segments = append(segments, p.newSegment())
+			current = &segments[len(segments)-1]
+		}
+		current.add(p.r.pos(), timeUS, hasTime)
+	}
+	p.reportProgress(StageSegments, p.lineNo, true)
+
+	finishSegments(segments)
+	if segments == nil {
+		segments = []Segment{}
+	}
+	p.segments = segments
+	return segments, p.Rewind()
+}
+
+// OpenSegment returns a parser restricted to seg, as returned by Segments
+// or ArmCycles.
+// It shares the schemas and filter of p but reads independently, with the
+// same offsets and LineNo values as p; Rewind returns to the start of the
+// segment and reading stops at its end, so TimeUS increases throughout.
+// The view must not be used after p is closed. Returns ErrNotSeekable if the
+// input can't be shared, such as a stream or a reader without io.ReaderAt.
+func (p *Parser) OpenSegment(seg Segment) (*Parser, error) {
+	if seg.End <= seg.Start {
+		return nil, fmt.Errorf("invalid segment [%d, %d)", seg.Start, seg.End)
+	}
+	r := p.r.clone()
+	if r == nil {
+		return nil, ErrNotSeekable
+	}
+
+	v := p.view(r)
+	v.opts.progress = p.opts.progress
+	v.opts.mode = p.opts.mode
+	v.start = seg.Start
+	v.startLineNo = seg.FirstLineNo - 1
+	v.limit = seg.End
+	if err := v.Rewind(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// newSegment returns an empty segment starting at the message just read.
+func (p *Parser) newSegment() Segment {
+	return Segment{
+		Start:       p.offset,
+		FirstLineNo: p.lineNo,
+		StartUS:     math.MaxInt64,
+		EndUS:       math.MinInt64,
+	}
+}
+
+// add counts a message ending at end in the segment.
+func (s *Segment) add(end, timeUS int64, hasTime bool) {
+	s.Messages++
+	s.End = end
+	if hasTime {
+		s.StartUS = min(s.StartUS, timeUS)
+		s.EndUS = max(s.EndUS, timeUS)
+	}
+}
+
+// finishSegments zeroes the time range of segments without TimeUS.
+func finishSegments(segments []Segment) {
+	for i := range segments {
+		if segments[i].StartUS > segments[i].EndUS {
+			segments[i].StartUS, segments[i].EndUS = 0, 0
+		}
+	}
+}
+
+// ArmCycles reads the whole log once and returns the parts written while the
+// vehicle was armed: each starts with an ARM message with a non-zero
+// ArmState, or an EV message with Id 10 (armed), and ends with the matching
+// disarm message, with Id 11 for EV, or where its boot or the log ends.
+// The parser is rewound when done. Returns ErrNotSeekable for stream
+// parsers.
+func (p *Parser) ArmCycles() ([]Segment, error) {
+	return p.ArmCyclesContext(context.Background())
+}
+
+// ArmCyclesContext is like ArmCycles but stops with ctx.Err() once ctx is
+// done.
+func (p *Parser) ArmCyclesContext(ctx context.Context) ([]Segment, error) {
+	boots, err := p.SegmentsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Rewind(); err != nil {
+		return nil, err
+	}
+
+	cycles := []Segment{}
+	var current *Segment
+	boot := 0
+	for {
+		if err := checkContext(ctx, p.lineNo); err != nil {
+			return nil, err
+		}
+
+		schema, body, err := p.skipMessage()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		p.reportProgress(StageSegments, p.lineNo, false)
+
+		for boot < len(boots) && p.offset >= boots[boot].End {
+			boot++
+			current = nil
+		}
+
+		armed, isArm := armState(schema, body)
+		if isArm && armed && current == nil {
+			cycles = append(cycles, p.newSegment())
+			current = &cycles[len(cycles)-1]
+		}
+		if current == nil {
+			continue
+		}
+
+		timeUS, hasTime := int64(0), false
+		if offset := schema.timeUSOffset(); offset >= 0 {
+			timeUS, hasTime = int64(binary.LittleEndian.Uint64(body[offset:])), true
+		}
+		current.add(p.r.pos(), timeUS, hasTime)
+		if isArm && !armed {
+			current = nil
+		}
+	}
+	p.reportProgress(StageSegments, p.lineNo, true)
+
+	finishSegments(cycles)
+	return cycles, p.Rewind()
+}
+
+// armState reports whether a message arms or disarms the vehicle, and
+// whether it is such a message at all.
+func armState(schema *Schema, body []byte) (armed, ok bool) {
+	r := FieldReader{schema: schema, body: body}
+	switch schema.Name {
+	case "ARM":
+		if i := schema.ColumnIndex("ArmState"); i >= 0 {
+			return r.Uint64(i) != 0, true
+		}
+	case "EV":
+		if i := schema.ColumnIndex("Id"); i >= 0 {
+			switch r.Uint64(i) {
+			case 10:
+				return true, true
+			case 11:
+				return false, true
+			}
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,135 @@
+package dataflash
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSegments(t *testing.T) {
+	data := redefinedLog(20)
+	first := len(sampleLog(20))
+
+	parser, err := NewParserFromBytes(data)
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	segments, err := parser.Segments()
+	if err != nil {
+		t.Fatalf("failed to find segments: %v", err)
+	}
+
+	expected := []Segment{
+		{Start: 0, End: int64(first), FirstLineNo: 1, Messages: 28, StartUS: 0, EndUS: 20000},
+		{Start: int64(first), End: int64(len(data)), FirstLineNo: 29, Messages: 28, StartUS: 0, EndUS: 20000},
+	}
+	if len(segments) != len(expected) {
+		t.Fatalf("expected %d segments, got %+v", len(expected), segments)
+	}
+	for i := range expected {
+		if segments[i] != expected[i] {
+			t.Errorf("segment %d: expected %+v, got %+v", i, expected[i], segments[i])
+		}
+	}
+
+	// The parser is rewound afterwards
+	msg, err := parser.ReadMessage()
+	if err != nil || msg.LineNo != 1 {
+		t.Fatalf("expected the first message after Segments, got %v, %v", msg, err)
+	}
+
+	view, err := parser.OpenSegment(segments[1])
+	if err != nil {
+		t.Fatalf("failed to open segment: %v", err)
+	}
+	if err := view.SetFilter("IMU"); err != nil {
+		t.Fatalf("failed to set filter: %v", err)
+	}
+	for range 2 {
+		var imu []*Message
+		for msg, err := range view.All() {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			imu = append(imu, msg)
+		}
+		if len(imu) != 20 {
+			t.Fatalf("expected 20 IMU messages, got %d", len(imu))
+		}
+		if imu[0].LineNo != 35 || imu[0].Offset < segments[1].Start || imu[0].Fields["AccX"] != float32(9.8) {
+			t.Errorf("expected the first IMU message of the second boot, got %+v", imu[0])
+		}
+		if err := view.Rewind(); err != nil {
+			t.Fatalf("failed to rewind: %v", err)
+		}
+	}
+
+	// Time ranges apply within the segment only
+	slice, err := view.GetSlice(5000, 7000, SliceByTimeUS)
+	if err != nil {
+		t.Fatalf("failed to get slice: %v", err)
+	}
+	if len(slice) != 2 || slice[0].Offset < segments[1].Start {
+		t.Errorf("expected 2 IMU messages of the second boot, got %d", len(slice))
+	}
+
+	// The view does not disturb the parser it came from
+	msg, err = parser.ReadMessage()
+	if err != nil || msg.LineNo != 2 {
+		t.Errorf("expected the second message, got %v, %v", msg, err)
+	}
+}
+
+func TestSegmentsTimeRegression(t *testing.T) {
+	var l testLog
+	l.writePreamble()
+	for i := range 10 {
+		l.writeIMU(uint64(5_000_000 + 1000*i))
+	}
+	// A small step back is not a reboot
+	l.writeIMU(5_000_000)
+	second := int64(l.Len())
+	for i := range 10 {
+		l.writeIMU(uint64(1000 * (i + 1)))
+	}
+
+	parser, err := NewParserFromBytes(l.Bytes())
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	segments, err := parser.Segments()
+	if err != nil {
+		t.Fatalf("failed to find segments: %v", err)
+	}
+	if len(segments) != 2 {
+		t.Fatalf("expected 2 segments, got %+v", segments)
+	}
+	if segments[0].Messages != 17 || segments[0].StartUS != 0 || segments[0].EndUS != 5_009_000 {
+		t.Errorf("unexpected first segment: %+v", segments[0])
+	}
+	if segments[1].Start != second || segments[1].FirstLineNo != 18 || segments[1].Messages != 10 ||
+		segments[1].StartUS != 1000 || segments[1].EndUS != 10000 {
+		t.Errorf("unexpected second segment: %+v", segments[1])
+	}
+
+	view, err := parser.OpenSegment(segments[1])
+	if err != nil {
+		t.Fatalf("failed to open segment: %v", err)
+	}
+	if err := view.SeekTimeUS(5000); err != nil {
+		t.Fatalf("failed to seek: %v", err)
+	}
+	msg, err := view.ReadMessage()
+	if err != nil || msg.TimeUS != 5000 || msg.LineNo != 22 {
+		t.Errorf("expected the message at 5000us in the second boot, got %v, %v", msg, err)
+	}
+}
+
+func TestSegmentsStream(t *testing.T) {
+	parser := NewStreamParser(bytes.NewReader(sampleLog(10)))
+	if _, err := parser.Segments(); err != ErrNotSeekable {
+		t.Errorf("expected ErrNotSeekable, got %v", err)
+	}
+	if _, err := parser.OpenSegment(Segment{Start: 0, End: 10}); err != ErrNotSeekable {
+		t.Errorf("expected ErrNotSeekable, got %v", err)
+	}
+}
@@ -0,0 +1,390 @@
+package dataflash
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+)
+
+const (
+	tlogTimestampSize = 8    // Big-endian microseconds since the Unix epoch
+	mavlink1Magic     = 0xfe // Start of a MAVLink 1 frame
+	mavlink2Magic     = 0xfd // Start of a MAVLink 2 frame
+	mavlink1Header    = 6    // Magic, length, sequence, system, component, message ID
+	mavlink2Header    = 10   // As MAVLink 1 with flags and a 3-byte message ID
+	mavlinkChecksum   = 2
+	mavlinkSignature  = 13
+	mavlinkSigned     = 0x01 // Incompatibility flag of signed MAVLink 2 frames
+)
+
+// TlogDiagnostics reports the frames a TlogParser skipped.
+type TlogDiagnostics struct {
+	BytesSkipped int64          // Bytes skipped while looking for the next record
+	BadCRC       int            // Frames dropped because their checksum did not match
+	UnknownIDs   map[uint32]int // Frames skipped per message ID missing from the dialect
+	Truncated    bool           // The log ends inside a record
+}
+
+// TlogParser reads MAVLink telemetry logs (.tlog files) as written by ground
+// stations such as Mission Planner and QGroundControl: records of an 8-byte
+// timestamp followed by a MAVLink 1 or 2 frame.
+//
+// Messages are returned as *Message values like those of Parser, so code
+// written against ReadMessage works on both:
+//   - Name is the MAVLink message name, such as GLOBAL_POSITION_INT
+//   - MsgID is the MAVLink message ID, up to 24 bits. Type holds the same ID
+//     if it is below 256 and is 0 otherwise, so use MsgID to tell messages
+//     apart
+//   - Fields holds the message fields under their MAVLink names, with the Go
+//     type matching the MAVLink type (uint16_t becomes uint16, float float32);
+//     char arrays are strings, uint8_t arrays []byte and other arrays slices.
+//     Extension fields missing from a frame are zero
+//   - TimeUS is the record timestamp in microseconds since the Unix epoch
+//   - LineNo counts the decoded messages and Offset is that of the record
+//
+// Every message of the ardupilotmega dialect and the dialects it includes,
+// such as common, is decoded, see Names. Frames are checked against the
+// CRC_EXTRA of the definition, so frames of a different version of a
+// message are dropped. Frames of other messages,
+// corrupt frames and bytes between records are skipped and reported by
+// Diagnostics. GetScaled is not available as tlogs carry no units.
+type TlogParser struct {
+	src     io.Reader
+	file    *os.File // Closed by Close if the parser opened it
+	r       *bufio.Reader
+	offset  int64 // Offset of the next byte of r
+	lineNo  int64
+	filter  map[string]bool
+	system  uint8 // System ID to keep, 0 for all
+	payload [255]byte
+	diag    TlogDiagnostics
+	seen    map[tlogAnomaly]bool // Anomalies recorded in diag
+}
+
+// tlogAnomaly identifies an anomaly recorded in TlogDiagnostics.
+type tlogAnomaly struct {
+	offset int64
+	kind   byte // 's' for skipped bytes, 'c' for a bad checksum, 'u' for an unknown ID
+}
+
+// NewTlogParser opens the telemetry log at filename.
+func NewTlogParser(filename string) (*TlogParser, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	p := NewTlogParserFromReader(f)
+	p.file = f
+	return p, nil
+}
+
+// NewTlogParserFromReader returns a parser reading a telemetry log from r.
+// Rewind and SetFilter need r to implement io.Seeker.
+func NewTlogParserFromReader(r io.Reader) *TlogParser {
+	return &TlogParser{src: r, r: bufio.NewReader(r)}
+}
+
+// Close closes the file opened by NewTlogParser. It does nothing for
+// parsers created from a reader.
+func (p *TlogParser) Close() error {
+	if p.file == nil {
+		return nil
+	}
+	return p.file.Close()
+}
+
+// Diagnostics returns the frames skipped so far. Anomalies are recorded
+// once, however many times the log is read.
+func (p *TlogParser) Diagnostics() TlogDiagnostics {
+	return p.diag
+}
+
+// Names returns the names of the messages the parser can decode.
+func (p *TlogParser) Names() []string {
+	names := make([]string, len(mavlinkDialect))
+	for i, m := range mavlinkDialect {
+		names[i] = m.Name
+	}
+	return names
+}
+
+// SetFilter restricts ReadMessage to messages with the given names and
+// rewinds the log. Names must be in the dialect, see Names.
+func (p *TlogParser) SetFilter(names ...string) error {
+	filter := make(map[string]bool)
+	var invalidNames []string
+	for _, name := range names {
+		if p.hasName(name) {
+			filter[name] = true
+		} else {
+			invalidNames = append(invalidNames, name)
+		}
+	}
+	if len(filter) == 0 {
+		return fmt.Errorf("no valid message types found in filter: %v", names)
+	}
+	if len(invalidNames) > 0 {
+		return fmt.Errorf("invalid message types in filter: %v", invalidNames)
+	}
+	p.filter = filter
+	return p.Rewind()
+}
+
+// ClearFilter removes the filter set by SetFilter.
+func (p *TlogParser) ClearFilter() {
+	p.filter = nil
+}
+
+// hasName reports whether the dialect defines a message called name.
+func (p *TlogParser) hasName(name string) bool {
+	for _, m := range mavlinkDialect {
+		if m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetSystem restricts ReadMessage to messages sent by the given MAVLink
+// system ID, such as the vehicle rather than the ground station (usually
+// 255). 0 returns messages of all systems.
+func (p *TlogParser) SetSystem(id uint8) {
+	p.system = id
+}
+
+// Rewind returns to the start of the log.
+// Returns ErrNotSeekable if the reader cannot seek.
+func (p *TlogParser) Rewind() error {
+	seeker, ok := p.src.(io.Seeker)
+	if !ok {
+		return ErrNotSeekable
+	}
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek: %w", err)
+	}
+	p.r.Reset(p.src)
+	p.offset = 0
+	p.lineNo = 0
+	return nil
+}
+
+// ReadMessage reads the next message that passes the filter. It returns
+// io.EOF at the end of the log, and io.ErrUnexpectedEOF if the log ends
+// inside a record.
+func (p *TlogParser) ReadMessage() (*Message, error) {
+	for {
+		msg, system, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if p.system != 0 && system != p.system {
+			continue
+		}
+		if p.filter != nil && !p.filter[msg.Name] {
+			continue
+		}
+		return msg, nil
+	}
+}
+
+// All returns an iterator over the messages from the current position to
+// the end of the log, with the same semantics as Parser.All.
+func (p *TlogParser) All() iter.Seq2[*Message, error] {
+	return p.AllContext(context.Background())
+}
+
+// AllContext is like All but stops with ctx.Err() once ctx is done.
+func (p *TlogParser) AllContext(ctx context.Context) iter.Seq2[*Message, error] {
+	return func(yield func(*Message, error) bool) {
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			msg, err := p.ReadMessage()
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(msg, nil) {
+				return
+			}
+		}
+	}
+}
+
+// next decodes the next record and returns its message and the system ID
+// that sent it. Frames that cannot be decoded are skipped; after a corrupt
+// frame the search for the next record goes byte by byte and only accepts
+// frames whose checksum can be verified, or frames of unknown messages
+// whose length leads to the start of another record.
+func (p *TlogParser) next() (*Message, uint8, error) {
+	runStart := int64(-1) // Offset of the bytes being skipped, -1 if none
+	for {
+		head, err := p.r.Peek(tlogTimestampSize + mavlink2Header)
+		if len(head) < tlogTimestampSize+2 {
+			p.endRun(runStart)
+			if len(head) == 0 && err == io.EOF {
+				return nil, 0, io.EOF
+			}
+			return nil, 0, p.truncated(err)
+		}
+
+		frame := head[tlogTimestampSize:]
+		var headerLen int
+		switch frame[0] {
+		case mavlink1Magic:
+			headerLen = mavlink1Header
+		case mavlink2Magic:
+			headerLen = mavlink2Header
+		default:
+			runStart = p.skip(runStart)
+			continue
+		}
+		recordLen := tlogTimestampSize + headerLen + int(frame[1]) + mavlinkChecksum
+		if frame[0] == mavlink2Magic && len(frame) > 2 && frame[2]&mavlinkSigned != 0 {
+			recordLen += mavlinkSignature
+		}
+
+		record, err := p.r.Peek(recordLen)
+		if len(record) < recordLen {
+			if runStart >= 0 {
+				runStart = p.skip(runStart)
+				continue
+			}
+			return nil, 0, p.truncated(err)
+		}
+		frame = record[tlogTimestampSize:]
+
+		var id uint32
+		var system uint8
+		if frame[0] == mavlink1Magic {
+			system, id = frame[3], uint32(frame[5])
+		} else {
+			system = frame[5]
+			id = uint32(frame[7]) | uint32(frame[8])<<8 | uint32(frame[9])<<16
+		}
+		def, ok := mavlinkMessages[id]
+		if !ok {
+			if runStart >= 0 && !p.followedByRecord(recordLen) {
+				runStart = p.skip(runStart)
+				continue
+			}
+			p.endRun(runStart)
+			runStart = -1
+			if p.anomaly(p.offset, 'u') {
+				if p.diag.UnknownIDs == nil {
+					p.diag.UnknownIDs = make(map[uint32]int)
+				}
+				p.diag.UnknownIDs[id]++
+			}
+			p.discard(recordLen)
+			continue
+		}
+
+		payloadLen := int(frame[1])
+		payload := frame[headerLen : headerLen+payloadLen]
+		crc := crcX25(crcX25Init, frame[1:headerLen+payloadLen])
+		crc = crcX25(crc, []byte{def.crcExtra})
+		if crc != binary.LittleEndian.Uint16(frame[headerLen+payloadLen:]) || payloadLen > def.maxLen {
+			if runStart < 0 && p.anomaly(p.offset, 'c') {
+				p.diag.BadCRC++
+			}
+			runStart = p.skip(runStart)
+			continue
+		}
+		p.endRun(runStart)
+
+		// MAVLink 2 drops trailing zeros from the payload
+		buf := p.payload[:def.maxLen]
+		clear(buf[copy(buf, payload):])
+
+		p.lineNo++
+		msg := &Message{
+			Name:   def.Name,
+			Fields: def.decode(buf),
+			LineNo: p.lineNo,
+			TimeUS: int64(binary.BigEndian.Uint64(record)),
+			Offset: p.offset,
+			MsgID:  id,
+		}
+		if id <= 0xff {
+			msg.Type = uint8(id)
+		}
+		p.discard(recordLen)
+		return msg, system, nil
+	}
+}
+
+// followedByRecord reports whether the record of recordLen bytes at the
+// current position ends at the end of the log or where another frame
+// starts.
+func (p *TlogParser) followedByRecord(recordLen int) bool {
+	next, _ := p.r.Peek(recordLen + tlogTimestampSize + 1)
+	if len(next) == recordLen {
+		return true
+	}
+	if len(next) < recordLen+tlogTimestampSize+1 {
+		return false
+	}
+	magic := next[recordLen+tlogTimestampSize]
+	return magic == mavlink1Magic || magic == mavlink2Magic
+}
+
+// discard consumes n bytes that were peeked.
+func (p *TlogParser) discard(n int) {
+	p.r.Discard(n)
+	p.offset += int64(n)
+}
+
+// skip consumes a byte that is not part of a record, in the run of skipped
+// bytes starting at runStart, or starting a run if runStart is -1. It
+// returns the start of the run.
+func (p *TlogParser) skip(runStart int64) int64 {
+	if runStart < 0 {
+		runStart = p.offset
+	}
+	p.discard(1)
+	return runStart
+}
+
+// endRun records the run of bytes skipped from runStart to the current
+// position, if any, as one anomaly.
+func (p *TlogParser) endRun(runStart int64) {
+	if runStart >= 0 && p.anomaly(runStart, 's') {
+		p.diag.BytesSkipped += p.offset - runStart
+	}
+}
+
+// truncated records a record cut off by the end of the log.
+func (p *TlogParser) truncated(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err == io.ErrUnexpectedEOF {
+		p.diag.Truncated = true
+	}
+	return err
+}
+
+// anomaly reports whether the anomaly of the given kind at offset is new,
+// remembering it so that it is recorded once when the log is read again.
+func (p *TlogParser) anomaly(offset int64, kind byte) bool {
+	key := tlogAnomaly{offset, kind}
+	if p.seen == nil {
+		p.seen = make(map[tlogAnomaly]bool)
+	}
+	if p.seen[key] {
+		return false
+	}
+	p.seen[key] = true
+	return true
+}
@@ -0,0 +1,270 @@
+package dataflash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"slices"
+	"testing"
+)
+
+const testTlogStart = 1_700_000_000_000_000 // Timestamp of the first record
+
+// testTlog builds telemetry logs in memory.
+type testTlog struct {
+	bytes.Buffer
+	seq uint8
+}
+
+// writeFrame appends a record with a MAVLink frame of the named message.
+// values are encoded in payload order; fields left out are zero. version 2
+// frames drop trailing zeros from the payload and carry a signature if
+// signed is set.
+func (l *testTlog) writeFrame(timeUS uint64, version int, system uint8, name string, values map[string]any, signed bool) {
+	var def *mavlinkMessage
+	for _, m := range mavlinkDialect {
+		if m.Name == name {
+			def = m
+		}
+	}
+	payload := make([]byte, def.maxLen)
+	for _, f := range def.wire {
+		if v, ok := values[f.Name]; ok {
+			var field bytes.Buffer
+			if s, ok := v.(string); ok {
+				v = []byte(s)
+			}
+			binary.Write(&field, binary.LittleEndian, v)
+			copy(payload[f.offset:f.offset+f.size()], field.Bytes())
+		}
+	}
+	if version == 1 {
+		payload = payload[:def.minLen]
+	} else {
+		for len(payload) > 1 && payload[len(payload)-1] == 0 {
+			payload = payload[:len(payload)-1]
+		}
+	}
+	l.writeRaw(timeUS, version, system, def.ID, payload, def.crcExtra, signed)
+}
+
+// writeRaw appends a record with a frame of the given message ID and
+// payload, with the checksum computed from crcExtra.
+func (l *testTlog) writeRaw(timeUS uint64, version int, system uint8, id uint32, payload []byte, crcExtra byte, signed bool) {
+	binary.Write(&l.Buffer, binary.BigEndian, timeUS)
+	var frame []byte
+	if version == 1 {
+		frame = []byte{mavlink1Magic, byte(len(payload)), l.seq, system, 1, byte(id)}
+	} else {
+		flags := byte(0)
+		if signed {
+			flags = mavlinkSigned
+		}
+		frame = []byte{mavlink2Magic, byte(len(payload)), flags, 0, l.seq, system, 1, byte(id), byte(id >> 8), byte(id >> 16)}
+	}
+	l.seq++
+	frame = append(frame, payload...)
+	crc := crcX25(crcX25Init, frame[1:])
+	crc = crcX25(crc, []byte{crcExtra})
+	frame = binary.LittleEndian.AppendUint16(frame, crc)
+	if signed {
+		frame = append(frame, make([]byte, mavlinkSignature)...)
+	}
+	l.Write(frame)
+}
+
+// sampleTlog returns a telemetry log with 7 messages, an unknown message,
+// a frame with a bad checksum and 5 bytes of garbage. It also returns the
+// number of bytes the parser skips.
+func sampleTlog() ([]byte, int64) {
+	var l testTlog
+	l.writeFrame(testTlogStart, 1, 1, "HEARTBEAT", map[string]any{
+		"type": uint8(2), "autopilot": uint8(3), "custom_mode": uint32(5), "mavlink_version": uint8(3),
+	}, false)
+	l.writeFrame(testTlogStart+1000, 2, 255, "HEARTBEAT", map[string]any{"type": uint8(6)}, false)
+	l.writeRaw(testTlogStart+2000, 2, 1, 42424, []byte{1, 2, 3}, 0, false) // Not in the dialect
+	l.writeFrame(testTlogStart+3000, 2, 1, "GLOBAL_POSITION_INT", map[string]any{
+		"time_boot_ms": uint32(60000), "lat": int32(504500000), "lon": int32(305200000),
+		"alt": int32(275000), "relative_alt": int32(10000), "vx": int16(-150),
+	}, false)
+
+	bad := l.Len()
+	l.writeFrame(testTlogStart+4000, 2, 1, "ATTITUDE", map[string]any{"roll": float32(0.5)}, false)
+	l.Bytes()[l.Len()-1] ^= 0xff // Corrupt the checksum
+	skipped := int64(l.Len() - bad)
+	l.Write([]byte{1, 2, 3, 4, 5})
+	skipped += 5
+
+	l.writeFrame(testTlogStart+5000, 2, 1, "STATUSTEXT", map[string]any{
+		"severity": uint8(6), "text": "ArduCopter V4.5.1", "id": uint16(7),
+	}, true)
+	l.writeFrame(testTlogStart+6000, 1, 1, "GPS_RAW_INT", map[string]any{
+		"time_usec": uint64(60_000_000), "fix_type": uint8(3), "lat": int32(504500000), "satellites_visible": uint8(12),
+		"h_acc": uint32(900), // Dropped from MAVLink 1 frames
+	}, false)
+	l.writeFrame(testTlogStart+7000, 2, 1, "VFR_HUD", map[string]any{
+		"groundspeed": float32(12.5), "heading": int16(270), "alt": float32(42),
+	}, false)
+	l.writeFrame(testTlogStart+8000, 2, 1, "ESC_TELEMETRY_1_TO_4", map[string]any{
+		"temperature": [4]uint8{30, 31, 32, 33}, "voltage": [4]uint16{1600, 1610, 1620, 1630},
+	}, false)
+	return l.Bytes(), skipped
+}
+
+func TestTlogParser(t *testing.T) {
+	data, skipped := sampleTlog()
+	parser := NewTlogParserFromReader(bytes.NewReader(data))
+
+	var messages []*Message
+	for msg, err := range parser.All() {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	var names []string
+	for _, msg := range messages {
+		names = append(names, msg.Name)
+	}
+	expected := []string{"HEARTBEAT", "HEARTBEAT", "GLOBAL_POSITION_INT", "STATUSTEXT", "GPS_RAW_INT", "VFR_HUD",
+		"ESC_TELEMETRY_1_TO_4"}
+	if !slices.Equal(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+
+	hb := messages[0]
+	if hb.Type != 0 || hb.TimeUS != testTlogStart || hb.LineNo != 1 || hb.Offset != 0 ||
+		hb.Fields["custom_mode"] != uint32(5) || hb.Fields["autopilot"] != uint8(3) {
+		t.Errorf("unexpected heartbeat %+v", hb)
+	}
+
+	pos := messages[2]
+	if lat, err := pos.Float64("lat"); err != nil || lat != 504500000 {
+		t.Errorf("expected lat 504500000, got %v, %v", lat, err)
+	}
+	if pos.MsgID != 33 || pos.Type != 33 || pos.Fields["vx"] != int16(-150) || pos.Fields["hdg"] != uint16(0) || pos.TimeUS != testTlogStart+3000 {
+		t.Errorf("unexpected position %+v", pos)
+	}
+	var position struct {
+		TimeBootMS uint32 `dataflash:"time_boot_ms"`
+		Lat        int64  `dataflash:"lat"`
+		Alt        int    `dataflash:"relative_alt"`
+	}
+	if err := pos.Unmarshal(&position); err != nil || position.TimeBootMS != 60000 || position.Alt != 10000 {
+		t.Errorf("failed to unmarshal: %+v, %v", position, err)
+	}
+
+	if text, _ := messages[3].String("text"); text != "ArduCopter V4.5.1" || messages[3].Fields["id"] != uint16(7) {
+		t.Errorf("unexpected status text %v", messages[3].Fields)
+	}
+	gps := messages[4]
+	if gps.Fields["satellites_visible"] != uint8(12) || gps.Fields["h_acc"] != uint32(0) {
+		t.Errorf("unexpected GPS fields %v", gps.Fields)
+	}
+	if hud := messages[5]; hud.Fields["groundspeed"] != float32(12.5) || hud.Fields["heading"] != int16(270) || hud.LineNo != 6 {
+		t.Errorf("unexpected VFR_HUD %+v", hud)
+	}
+	esc := messages[6]
+	if esc.MsgID != 11030 || esc.Type != 0 || !slices.Equal(esc.Fields["voltage"].([]uint16), []uint16{1600, 1610, 1620, 1630}) ||
+		!bytes.Equal(esc.Fields["temperature"].([]byte), []byte{30, 31, 32, 33}) {
+		t.Errorf("unexpected ESC telemetry %+v", esc)
+	}
+
+	diag := parser.Diagnostics()
+	if diag.BadCRC != 1 || diag.BytesSkipped != skipped || diag.UnknownIDs[42424] != 1 || diag.Truncated {
+		t.Errorf("unexpected diagnostics %+v, expected %d bytes skipped", diag, skipped)
+	}
+}
+
+func TestTlogParserFilter(t *testing.T) {
+	data, _ := sampleTlog()
+	parser := NewTlogParserFromReader(bytes.NewReader(data))
+	for range parser.All() {
+	}
+
+	if err := parser.SetFilter("HEARTBEAT", "VFR_HUD"); err != nil {
+		t.Fatalf("failed to set filter: %v", err)
+	}
+	var lineNos []int64
+	for msg, err := range parser.All() {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lineNos = append(lineNos, msg.LineNo)
+	}
+	if !slices.Equal(lineNos, []int64{1, 2, 6}) {
+		t.Errorf("expected LineNo 1, 2 and 6, got %v", lineNos)
+	}
+
+	parser.SetSystem(255)
+	parser.Rewind()
+	if msg, err := parser.ReadMessage(); err != nil || msg.LineNo != 2 {
+		t.Errorf("expected the ground station heartbeat, got %+v, %v", msg, err)
+	}
+	if _, err := parser.ReadMessage(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+	if diag := parser.Diagnostics(); diag.BadCRC != 1 || diag.UnknownIDs[42424] != 1 {
+		t.Errorf("expected anomalies to be recorded once, got %+v", diag)
+	}
+
+	if err := parser.SetFilter("GPS"); err == nil {
+		t.Error("expected an error for a name outside the dialect")
+	}
+	stream := NewTlogParserFromReader(streamOnly{bytes.NewReader(data)})
+	if err := stream.Rewind(); err != ErrNotSeekable {
+		t.Errorf("expected ErrNotSeekable, got %v", err)
+	}
+}
+
+func TestTlogParserTruncated(t *testing.T) {
+	data, _ := sampleTlog()
+	parser := NewTlogParserFromReader(bytes.NewReader(data[:len(data)-3]))
+	count := 0
+	var err error
+	for {
+		if _, err = parser.ReadMessage(); err != nil {
+			break
+		}
+		count++
+	}
+	if err != io.ErrUnexpectedEOF || count != 6 || !parser.Diagnostics().Truncated {
+		t.Errorf("expected 6 messages and io.ErrUnexpectedEOF, got %d and %v", count, err)
+	}
+}
+
+func TestTlogParserResync(t *testing.T) {
+	var l testTlog
+	l.writeFrame(testTlogStart, 2, 1, "HEARTBEAT", map[string]any{"type": uint8(2)}, false)
+	bad := l.Len()
+	l.writeFrame(testTlogStart+1000, 2, 1, "ATTITUDE", map[string]any{"roll": float32(0.5)}, false)
+	l.Bytes()[l.Len()-1] ^= 0xff // Corrupt the checksum
+	badLen := int64(l.Len() - bad)
+	l.writeRaw(testTlogStart+2000, 2, 1, 42424, []byte{1, 2, 3}, 0, false) // Found while resyncing
+	l.writeFrame(testTlogStart+3000, 2, 1, "HEARTBEAT", map[string]any{"type": uint8(6)}, false)
+	l.Write(bytes.Repeat([]byte{0x55}, 10000))
+	l.writeFrame(testTlogStart+4000, 2, 1, "HEARTBEAT", map[string]any{"type": uint8(6)}, false)
+
+	parser := NewTlogParserFromReader(bytes.NewReader(l.Bytes()))
+	for range 2 {
+		count := 0
+		for _, err := range parser.All() {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			count++
+		}
+		if count != 3 {
+			t.Errorf("expected 3 messages, got %d", count)
+		}
+		diag := parser.Diagnostics()
+		if diag.BadCRC != 1 || diag.UnknownIDs[42424] != 1 || diag.BytesSkipped != badLen+10000 {
+			t.Errorf("unexpected diagnostics %+v, expected %d bytes skipped", diag, badLen+10000)
+		}
+		parser.Rewind()
+	}
+	if len(parser.seen) != 4 {
+		t.Errorf("expected one anomaly per skipped run and frame, got %d", len(parser.seen))
+	}
+}
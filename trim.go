@@ -0,0 +1,151 @@
+package dataflash
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Trim writes the messages of src within the range to dst as a standalone
+// log. start and end select messages as in GetSlice, except that messages
+// without TimeUS take the TimeUS of the message before them. The messages
+// are copied byte for byte, preceded by the FMT, FMTU, UNIT, MULT, PARM, VER
+// and MSG messages of the boot that were written before the range, so that
+// the result can be read on its own. src may be a segment view from
+// OpenSegment. The parser is rewound when done.
+// Returns ErrNotSeekable for stream parsers.
+func Trim(src *Parser, dst io.Writer, start, end int64, sliceType SliceType) error {
+	return TrimContext(context.Background(), src, dst, start, end, sliceType)
+}
+
+// TrimContext is like Trim but stops with ctx.Err() once ctx is done.
+func TrimContext(ctx context.Context, src *Parser, dst io.Writer, start, end int64, sliceType SliceType) error {
+	if sliceType != SliceByLineNo && sliceType != SliceByTimeUS {
+		return fmt.Errorf("invalid slice type: %s", sliceType)
+	}
+	return src.trim(ctx, dst, func(offset, lineNo, timeUS int64) (bool, bool) {
+		value := lineNo
+		if sliceType == SliceByTimeUS {
+			value = timeUS
+		}
+		return value >= start && value < end, value >= end
+	})
+}
+
+// WriteSegment writes the messages of seg, as returned by Segments or
+// ArmCycles, to dst as a standalone log, preceded by the preamble messages
+// the segment needs as in Trim.
+func WriteSegment(src *Parser, dst io.Writer, seg Segment) error {
+	return src.trim(context.Background(), dst, func(offset, lineNo, timeUS int64) (bool, bool) {
+		return offset >= seg.Start && offset < seg.End, offset >= seg.End
+	})
+}
+
+// Split writes each segment to the writer create returns for it, as
+// WriteSegment does, for example to store every boot or arm cycle of a log
+// in its own file. If the writer is an io.Closer, it is closed once the
+// segment is written.
+func Split(src *Parser, segments []Segment, create func(i int, seg Segment) (io.Writer, error)) error {
+	for i, seg := range segments {
+		w, err := create(i, seg)
+		if err != nil {
+			return fmt.Errorf("failed to create segment %d: %w", i, err)
+		}
+		err = WriteSegment(src, w, seg)
+		if c, ok := w.(io.Closer); ok {
+			if closeErr := c.Close(); err == nil && closeErr != nil {
+				err = fmt.Errorf("failed to close segment %d: %w", i, closeErr)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isPreambleMessage reports whether messages with the given name describe
+// the log or vehicle rather than a moment of the flight, and are copied
+// ahead of a trimmed range.
+func isPreambleMessage(name string) bool {
+	switch name {
+	case "FMT", "FMTU", "UNIT", "MULT", "PARM", "VER", "MSG":
+		return true
+	default:
+		return false
+	}
+}
+
+// trim copies the messages that keep selects to dst, preceded by the
+// preamble messages before the first of them. keep is called for each
+// message of p in order with its offset, LineNo and TimeUS (or that of the
+// last message with one) and reports whether to copy it and whether the
+// range has ended.
+func (p *Parser) trim(ctx context.Context, dst io.Writer, keep func(offset, lineNo, timeUS int64) (bool, bool)) error {
+	// The preamble may be before the start of a segment view
+	if err := p.r.seek(0); err != nil {
+		return err
+	}
+	p.lineNo = 0
+	p.progressAt = 0
+
+	w := bufio.NewWriter(dst)
+	var preamble []byte
+	started := false
+	var timeUS int64
+	for {
+		if err := checkContext(ctx, p.lineNo); err != nil {
+			return err
+		}
+
+		schema, body, err := p.skipMessage()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		p.reportProgress(StageTrim, p.lineNo, false)
+
+		// FMT for FMT starts the preamble of a new boot
+		if schema.Name == "FMT" && len(body) > 0 && body[0] == FMTType {
+			timeUS = 0
+			if !started {
+				preamble = preamble[:0]
+			}
+		}
+		if offset := schema.timeUSOffset(); offset >= 0 {
+			timeUS = int64(binary.LittleEndian.Uint64(body[offset:]))
+		}
+
+		copyIt, done := false, false
+		if p.offset >= p.start {
+			copyIt, done = keep(p.offset, p.lineNo, timeUS)
+		}
+		if done {
+			break
+		}
+		if copyIt {
+			if !started {
+				w.Write(preamble)
+				started = true
+			}
+			w.Write([]byte{HEAD1, HEAD2, schema.Type})
+			w.Write(body)
+		} else if !started && isPreambleMessage(schema.Name) {
+			preamble = append(preamble, HEAD1, HEAD2, schema.Type)
+			preamble = append(preamble, body...)
+		}
+	}
+	p.reportProgress(StageTrim, p.lineNo, true)
+
+	if !started {
+		w.Write(preamble)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write trimmed log: %w", err)
+	}
+	return p.Rewind()
+}
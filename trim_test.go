@@ -0,0 +1,267 @@
+package dataflash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"maps"
+	"testing"
+)
+
+var (
+	testParmSchema = &Schema{Type: 150, Length: 31, Name: "PARM", Format: "QNf", Columns: "TimeUS,Name,Value"}
+	testMsgSchema  = &Schema{Type: 151, Length: 75, Name: "MSG", Format: "QZ", Columns: "TimeUS,Message"}
+	testArmSchema  = &Schema{Type: 152, Length: 18, Name: "ARM", Format: "QbIbB", Columns: "TimeUS,ArmState,ArmChecks,Forced,Method"}
+	testEVSchema   = &Schema{Type: 153, Length: 12, Name: "EV", Format: "QB", Columns: "TimeUS,Id"}
+)
+
+// flightLog returns a log of two boots. The first has parameters and
+// messages, IMU messages 1ms apart for 100ms and a GPS message every 10ms;
+// it is armed by an ARM message at 20ms, disarmed by EV at 50ms and armed
+// again by EV at 70ms, with a MSG at 40ms. The second boot has 10 IMU
+// messages and is armed from 5ms to 8ms.
+func flightLog() []byte {
+	var l testLog
+	l.writePreamble()
+	for _, s := range []*Schema{testParmSchema, testMsgSchema, testArmSchema, testEVSchema} {
+		l.writeFMT(s)
+	}
+	l.writeParm(0, "RATE", 400)
+	l.writeParm(0, "MODE", 5)
+	l.writeMsg(0, "ArduCopter V4.5")
+	for i := 1; i <= 100; i++ {
+		timeUS := uint64(1000 * i)
+		l.writeIMU(timeUS)
+		switch i {
+		case 20:
+			l.writeMessage(testArmSchema.Type, timeUS, int8(1), uint32(0), int8(0), uint8(0))
+		case 40:
+			l.writeMsg(timeUS, "mid")
+		case 50:
+			l.writeMessage(testEVSchema.Type, timeUS, uint8(11))
+		case 70:
+			l.writeMessage(testEVSchema.Type, timeUS, uint8(10))
+		}
+		if i%10 == 0 {
+			l.writeGPS(timeUS, 50.45, 30.52)
+		}
+	}
+
+	l.writePreamble()
+	l.writeFMT(testParmSchema)
+	l.writeFMT(testArmSchema)
+	l.writeParm(0, "RATE", 200)
+	for i := 1; i <= 10; i++ {
+		timeUS := uint64(1000 * i)
+		l.writeIMU(timeUS)
+		switch i {
+		case 5:
+			l.writeMessage(testArmSchema.Type, timeUS, int8(1), uint32(0), int8(0), uint8(0))
+		case 8:
+			l.writeMessage(testArmSchema.Type, timeUS, int8(0), uint32(0), int8(0), uint8(0))
+		}
+	}
+	return l.Bytes()
+}
+
+// writeParm appends a PARM message.
+func (l *testLog) writeParm(timeUS uint64, name string, value float32) {
+	l.writeMessage(testParmSchema.Type, timeUS)
+	l.writeString(name, 16)
+	binary.Write(&l.Buffer, binary.LittleEndian, value)
+}
+
+// writeMsg appends a MSG message.
+func (l *testLog) writeMsg(timeUS uint64, text string) {
+	l.writeMessage(testMsgSchema.Type, timeUS)
+	l.writeString(text, 64)
+}
+
+// readTrimmed parses a trimmed log and returns its messages.
+func readTrimmed(t *testing.T, data []byte) []*Message {
+	t.Helper()
+	parser, err := NewParserFromBytes(data)
+	if err != nil {
+		t.Fatalf("failed to parse trimmed log: %v", err)
+	}
+	var messages []*Message
+	for msg, err := range parser.All() {
+		if err != nil {
+			t.Fatalf("failed to read trimmed log: %v", err)
+		}
+		messages = append(messages, msg)
+	}
+	if d := parser.Diagnostics(); len(d.Resyncs) > 0 || d.Truncated {
+		t.Errorf("trimmed log is corrupt: %+v", d)
+	}
+	if len(messages) == 0 || messages[0].Name != "FMT" || messages[0].Fields["Type"] != uint8(FMTType) {
+		t.Fatal("expected the trimmed log to start with FMT for FMT")
+	}
+	return messages
+}
+
+// names counts messages by name.
+func names(messages []*Message) map[string]int {
+	counts := make(map[string]int)
+	for _, msg := range messages {
+		counts[msg.Name]++
+	}
+	return counts
+}
+
+func TestTrim(t *testing.T) {
+	data := flightLog()
+	parser, err := NewParserFromBytes(data)
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := Trim(parser, &out, 45000, 55000, SliceByTimeUS); err != nil {
+		t.Fatalf("failed to trim: %v", err)
+	}
+	messages := readTrimmed(t, out.Bytes())
+
+	expected := map[string]int{"FMT": 8, "FMTU": 2, "PARM": 2, "MSG": 2, "IMU": 10, "GPS": 1, "EV": 1}
+	if got := names(messages); !maps.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if messages[len(messages)-1].TimeUS != 54000 {
+		t.Errorf("expected the range to end at 54ms, got %d", messages[len(messages)-1].TimeUS)
+	}
+	if _, unit, _ := messages[len(messages)-1].GetScaled("TimeUS"); unit != "s" {
+		t.Errorf("expected units to be preserved, got %q", unit)
+	}
+
+	// The parser is rewound
+	if msg, err := parser.ReadMessage(); err != nil || msg.LineNo != 1 {
+		t.Errorf("expected the parser to be rewound, got %v, %v", msg, err)
+	}
+
+	// The range is copied byte for byte
+	slice, err := parser.GetSlice(45000, 55000, SliceByTimeUS)
+	if err != nil {
+		t.Fatalf("failed to get slice: %v", err)
+	}
+	first, last := slice[0], slice[len(slice)-1]
+	lastLen := int64(parser.SchemaAt(last.Type, last.Offset).Length)
+	if !bytes.HasSuffix(out.Bytes(), data[first.Offset:last.Offset+lastLen]) {
+		t.Error("expected the range to be copied unchanged")
+	}
+
+	out.Reset()
+	if err := Trim(parser, &out, 1, 4, SliceByLineNo); err != nil {
+		t.Fatalf("failed to trim: %v", err)
+	}
+	if got := names(readTrimmed(t, out.Bytes())); !maps.Equal(got, map[string]int{"FMT": 3}) {
+		t.Errorf("expected the first 3 messages, got %v", got)
+	}
+
+	if err := Trim(parser, &out, 0, 1, "Offset"); err == nil {
+		t.Error("expected an error for an invalid slice type")
+	}
+}
+
+func TestTrimSegmentView(t *testing.T) {
+	parser, err := NewParserFromBytes(flightLog())
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	segments, err := parser.Segments()
+	if err != nil || len(segments) != 2 {
+		t.Fatalf("expected 2 segments, got %v, %v", segments, err)
+	}
+	view, err := parser.OpenSegment(segments[1])
+	if err != nil {
+		t.Fatalf("failed to open segment: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := Trim(view, &out, 0, 6000, SliceByTimeUS); err != nil {
+		t.Fatalf("failed to trim: %v", err)
+	}
+	// Nothing of the first boot is copied
+	expected := map[string]int{"FMT": 6, "FMTU": 2, "PARM": 1, "IMU": 5, "ARM": 1}
+	if got := names(readTrimmed(t, out.Bytes())); !maps.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestWriteSegmentWithoutPreamble(t *testing.T) {
+	var l testLog
+	l.writePreamble()
+	for i := range 10 {
+		l.writeIMU(uint64(5_000_000 + 1000*i))
+	}
+	for i := range 10 {
+		l.writeIMU(uint64(1000 * (i + 1)))
+	}
+
+	parser, err := NewParserFromBytes(l.Bytes())
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	segments, err := parser.Segments()
+	if err != nil || len(segments) != 2 {
+		t.Fatalf("expected 2 segments, got %v, %v", segments, err)
+	}
+
+	var out bytes.Buffer
+	if err := WriteSegment(parser, &out, segments[1]); err != nil {
+		t.Fatalf("failed to write segment: %v", err)
+	}
+	messages := readTrimmed(t, out.Bytes())
+	if got := names(messages); !maps.Equal(got, map[string]int{"FMT": 4, "FMTU": 2, "IMU": 10}) {
+		t.Errorf("expected the preamble and 10 IMU messages, got %v", got)
+	}
+	if messages[6].TimeUS != 1000 {
+		t.Errorf("expected the segment to start at 1ms, got %d", messages[6].TimeUS)
+	}
+}
+
+func TestArmCyclesAndSplit(t *testing.T) {
+	parser, err := NewParserFromBytes(flightLog())
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	cycles, err := parser.ArmCycles()
+	if err != nil {
+		t.Fatalf("failed to find arm cycles: %v", err)
+	}
+
+	expected := []struct {
+		messages, startUS, endUS int64
+	}{
+		{36, 20000, 50000},
+		{35, 70000, 100000},
+		{5, 5000, 8000},
+	}
+	if len(cycles) != len(expected) {
+		t.Fatalf("expected %d arm cycles, got %+v", len(expected), cycles)
+	}
+	for i, want := range expected {
+		got := cycles[i]
+		if got.Messages != want.messages || got.StartUS != want.startUS || got.EndUS != want.endUS {
+			t.Errorf("cycle %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+
+	var parts []*bytes.Buffer
+	err = Split(parser, cycles, func(i int, seg Segment) (io.Writer, error) {
+		parts = append(parts, &bytes.Buffer{})
+		return parts[i], nil
+	})
+	if err != nil {
+		t.Fatalf("failed to split: %v", err)
+	}
+	for i, part := range parts {
+		messages := readTrimmed(t, part.Bytes())
+		counts := names(messages)
+		if data := counts["IMU"] + counts["GPS"] + counts["ARM"] + counts["EV"]; int64(data) > cycles[i].Messages {
+			t.Errorf("part %d: expected at most %d messages of the cycle, got %v", i, cycles[i].Messages, counts)
+		}
+		if last := messages[len(messages)-1]; last.TimeUS != cycles[i].EndUS {
+			t.Errorf("part %d: expected to end at %d, got %d", i, cycles[i].EndUS, last.TimeUS)
+		}
+	}
+}
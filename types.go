@@ -1,7 +1,12 @@
 package dataflash
 
+import "sync"
+
 // Schema represents a message format definition (FMT message).
-// It describes how to decode a specific message type.
+// It describes how to decode a specific message type. Its field table is
+// compiled once, when the parser reads the FMT message or on the first use
+// of a schema built by the caller; the fields must not change after that.
+// A compiled schema is safe for concurrent use.
 type Schema struct {
 	Type    uint8  // Message type ID
 	Length  uint8  // Total message length including 3-byte header
@@ -10,6 +15,13 @@ type Schema struct {
 	Columns string // Comma-separated column names
 	Units   string // Unit identifiers per field (from FMTU)
 	Mults   string // Multiplier identifiers per field (from FMTU)
+
+	fields []FieldDef     // Compiled field table, see Fields
+	index  map[string]int // Field index by column name
+	timeUS int            // Body offset of a Q/q TimeUS field, or -1
+	size   int            // Sum of the field sizes
+	err    error          // Why messages of this schema cannot be decoded
+	once   sync.Once      // Guards compile
 }
 
 // Message represents a parsed DataFlash message with its decoded field values.
@@ -19,6 +31,8 @@ type Message struct {
 	Fields map[string]any // Decoded field values
 	LineNo int64          // Message sequence number in the log
 	TimeUS int64          // Microseconds since boot (0 if not available)
+	Offset int64          // Byte offset of the message header in the log
+	MsgID  uint32         // MAVLink message ID of messages read by TlogParser (0 otherwise)
 	schema *Schema        // Reference to schema for unit/mult lookups
 }
 
@@ -0,0 +1,248 @@
+package dataflash
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// UnmarshalTypeError reports a field value that cannot be stored in the
+// struct field it maps to without losing information.
+type UnmarshalTypeError struct {
+	Message string       // Message name
+	Column  string       // Column name
+	Value   reflect.Type // Type of the decoded (or scaled) value
+	Field   string       // Struct field name
+	Type    reflect.Type // Type of the struct field
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	return fmt.Sprintf("cannot unmarshal %s.%s (%s) into field %s of type %s",
+		e.Message, e.Column, e.Value, e.Field, e.Type)
+}
+
+// structField maps a struct field to a message column.
+type structField struct {
+	index  int    // Index of the field in the struct
+	name   string // Go name of the field
+	column string // Column name
+	scaled bool   // Apply the FMTU multiplier, as GetScaled does
+}
+
+// structInfo describes how messages are unmarshalled into a struct type.
+type structInfo struct {
+	name   string // Message name for Decode
+	fields []structField
+}
+
+// structInfos caches structInfo by reflect.Type.
+var structInfos sync.Map
+
+// structInfoOf returns the mapping of columns to the fields of struct type t.
+// Exported fields map to the column named by their dataflash tag, or to the
+// column of the same name if they have none; a tag of "-" skips the field.
+// A ",scaled" option applies the FMTU multiplier. The tag of a blank field
+// names the message, which otherwise is the name of the type.
+func structInfoOf(t reflect.Type) *structInfo {
+	if info, ok := structInfos.Load(t); ok {
+		return info.(*structInfo)
+	}
+
+	info := &structInfo{name: t.Name()}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup("dataflash")
+		if f.Name == "_" {
+			if hasTag {
+				info.name = tag
+			}
+			continue
+		}
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+
+		column, options, _ := strings.Cut(tag, ",")
+		if column == "" {
+			column = f.Name
+		}
+		info.fields = append(info.fields, structField{
+			index:  i,
+			name:   f.Name,
+			column: column,
+			scaled: options == "scaled",
+		})
+	}
+
+	actual, _ := structInfos.LoadOrStore(t, info)
+	return actual.(*structInfo)
+}
+
+// Unmarshal stores the field values of the message in the struct v points
+// to. Fields are matched to columns by their dataflash tag:
+//
+//	type GPS struct {
+//		TimeUS uint64
+//		Lat    float64 `dataflash:"Lat"`
+//		Alt    float64 `dataflash:"Alt,scaled"`
+//		Ignore string  `dataflash:"-"`
+//	}
+//
+// Untagged exported fields match the column of the same name. Values may be
+// widened to a larger integer or float type, such as uint8 to int or int32
+// to float64, but never narrowed; a value that does not fit returns an
+// *UnmarshalTypeError. With the scaled option the value is scaled as by
+// GetScaled first. Struct fields whose column is missing from the message
+// are left unchanged.
+func (m *Message) Unmarshal(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("failed to unmarshal %s: need a non-nil struct pointer, got %T", m.Name, v)
+	}
+	return m.unmarshal(rv.Elem(), structInfoOf(rv.Elem().Type()))
+}
+
+func (m *Message) unmarshal(dst reflect.Value, info *structInfo) error {
+	for _, f := range info.fields {
+		value, ok := m.Fields[f.column]
+		if !ok {
+			continue
+		}
+		if f.scaled {
+			var err error
+			if value, _, err = m.GetScaled(f.column); err != nil {
+				return fmt.Errorf("failed to scale %s.%s: %w", m.Name, f.column, err)
+			}
+		}
+
+		field := dst.Field(f.index)
+		if !assign(field, value) {
+			return &UnmarshalTypeError{
+				Message: m.Name,
+				Column:  f.column,
+				Value:   reflect.TypeOf(value),
+				Field:   f.name,
+				Type:    field.Type(),
+			}
+		}
+	}
+	return nil
+}
+
+// assign stores value in dst if it fits without loss and reports whether
+// it did.
+func assign(dst reflect.Value, value any) bool {
+	src := reflect.ValueOf(value)
+	if !src.IsValid() {
+		return false
+	}
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return true
+	}
+
+	srcSize, dstSize := src.Type().Size(), dst.Type().Size()
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch {
+		case isInt(src.Kind()) && srcSize <= dstSize:
+			dst.SetInt(src.Int())
+			return true
+		case isUint(src.Kind()) && srcSize < dstSize:
+			dst.SetInt(int64(src.Uint()))
+			return true
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if isUint(src.Kind()) && srcSize <= dstSize {
+			dst.SetUint(src.Uint())
+			return true
+		}
+
+	case reflect.Float32, reflect.Float64:
+		// Integers are exact in a float whose mantissa is wider than them:
+		// 24 bits for float32 and 53 for float64
+		exact := srcSize <= 2 || srcSize <= 4 && dstSize == 8
+		switch {
+		case src.Kind() == reflect.Float32 || src.Kind() == reflect.Float64:
+			if srcSize <= dstSize {
+				dst.SetFloat(src.Float())
+				return true
+			}
+		case isInt(src.Kind()) && exact:
+			dst.SetFloat(float64(src.Int()))
+			return true
+		case isUint(src.Kind()) && exact:
+			dst.SetFloat(float64(src.Uint()))
+			return true
+		}
+
+	case reflect.String:
+		if src.Kind() == reflect.String {
+			dst.SetString(src.String())
+			return true
+		}
+
+	case reflect.Array:
+		// Arrays such as [32]int16 take the []int16 of 'a' fields
+		if src.Kind() == reflect.Slice && src.Len() == dst.Len() &&
+			src.Type().Elem().ConvertibleTo(dst.Type().Elem()) &&
+			src.Type().Elem().Kind() == dst.Type().Elem().Kind() {
+			for i := range src.Len() {
+				dst.Index(i).Set(src.Index(i).Convert(dst.Type().Elem()))
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func isInt(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUint(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uint64
+}
+
+// Decode returns an iterator that unmarshals every message of the type T
+// describes into a T, as Message.Unmarshal does. The message name is the
+// name of T, or the dataflash tag of a blank field:
+//
+//	type Position struct {
+//		_   struct{} `dataflash:"GPS"`
+//		Lat float64
+//		Lng float64
+//	}
+//
+// Like Filtered, Decode sets the parser's filter to that message, which
+// rewinds the log. Iteration ends at the first error, which is yielded with
+// the zero T.
+func Decode[T any](p *Parser) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		t := reflect.TypeFor[T]()
+		if t.Kind() != reflect.Struct {
+			yield(zero, fmt.Errorf("failed to decode: %s is not a struct", t))
+			return
+		}
+
+		info := structInfoOf(t)
+		for msg, err := range p.Filtered(info.name) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			var v T
+			if err := msg.unmarshal(reflect.ValueOf(&v).Elem(), info); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package dataflash
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+type testGPS struct {
+	_      struct{} `dataflash:"GPS"`
+	TimeUS uint64
+	Time   float64 `dataflash:"TimeUS,scaled"`
+	Status int     `dataflash:"Status"`
+	Lat    float64 `dataflash:"Lat"`
+	Lng    float64
+	Alt    float64 `dataflash:"Alt,scaled"`
+	Note   string  `dataflash:"-"`
+	Extra  string  `dataflash:"Missing"`
+	hidden int
+}
+
+func TestDecode(t *testing.T) {
+	parser, err := NewParserFromBytes(sampleLog(100))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	var gps []testGPS
+	for v, err := range Decode[testGPS](parser) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		gps = append(gps, v)
+	}
+	if len(gps) != 10 {
+		t.Fatalf("expected 10 GPS messages, got %d", len(gps))
+	}
+
+	got := gps[0]
+	if got.TimeUS != 10000 || math.Abs(got.Time-0.01) > 1e-12 {
+		t.Errorf("expected TimeUS 10000 (0.01 s), got %d (%v s)", got.TimeUS, got.Time)
+	}
+	if got.Status != 3 || math.Abs(got.Lat-50.45) > 1e-6 || math.Abs(got.Lng-30.52) > 1e-6 || got.Alt != 275.3 {
+		t.Errorf("unexpected values: %+v", got)
+	}
+	if got.Note != "" || got.Extra != "" || got.hidden != 0 {
+		t.Errorf("expected skipped fields to stay empty: %+v", got)
+	}
+}
+
+func TestDecodeTypeName(t *testing.T) {
+	type IMU struct {
+		I    uint16
+		GyrX float64
+		GyrY float32
+	}
+
+	parser, err := NewParserFromBytes(sampleLog(10))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	count := 0
+	for v, err := range Decode[IMU](parser) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v.GyrX != float64(float32(0.1)) || v.GyrY != 0.2 {
+			t.Errorf("unexpected values: %+v", v)
+		}
+		count++
+	}
+	if count != 10 {
+		t.Errorf("expected 10 IMU messages, got %d", count)
+	}
+
+	for _, err := range Decode[int](parser) {
+		if err == nil {
+			t.Error("expected an error for a non-struct type")
+		}
+	}
+}
+
+func TestUnmarshalTypeMismatch(t *testing.T) {
+	parser, err := NewParserFromBytes(sampleLog(10))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if err := parser.SetFilter("IMU"); err != nil {
+		t.Fatalf("failed to set filter: %v", err)
+	}
+	msg, err := parser.ReadMessage()
+	if err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		v      any
+		column string
+	}{
+		{"unsigned into signed of the same size", &struct{ TimeUS int64 }{}, "TimeUS"},
+		{"float into integer", &struct{ GyrX int64 }{}, "GyrX"},
+		{"64-bit integer into float", &struct{ TimeUS float64 }{}, "TimeUS"},
+		{"number into string", &struct{ I string }{}, "I"},
+		{"narrowing", &struct {
+			I uint8
+			X int8 `dataflash:"TimeUS"`
+		}{}, "TimeUS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := msg.Unmarshal(tt.v)
+			var typeErr *UnmarshalTypeError
+			if !errors.As(err, &typeErr) {
+				t.Fatalf("expected *UnmarshalTypeError, got %v", err)
+			}
+			if typeErr.Message != "IMU" || typeErr.Column != tt.column {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	var notPointer struct{ TimeUS uint64 }
+	if err := msg.Unmarshal(notPointer); err == nil {
+		t.Error("expected an error for a non-pointer")
+	}
+}
+
+func TestUnmarshalArray(t *testing.T) {
+	type sample int16
+	msg := &Message{Name: "ISBD", Fields: map[string]any{
+		"Mode": uint8(3),
+		"x":    []int16{1, -2, 3},
+	}}
+
+	var v struct {
+		Mode  sample
+		X     [3]int16  `dataflash:"x"`
+		Slice []int16   `dataflash:"x"`
+		Named [3]sample `dataflash:"x"`
+		Any   any       `dataflash:"Mode"`
+	}
+	if err := msg.Unmarshal(&v); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Mode != 3 || v.X != [3]int16{1, -2, 3} || !reflect.DeepEqual(v.Slice, []int16{1, -2, 3}) ||
+		v.Named != [3]sample{1, -2, 3} || v.Any != uint8(3) {
+		t.Errorf("unexpected values: %+v", v)
+	}
+
+	var short struct {
+		X [2]int16 `dataflash:"x"`
+	}
+	if err := msg.Unmarshal(&short); err == nil {
+		t.Error("expected an error for an array of the wrong length")
+	}
+}
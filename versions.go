@@ -0,0 +1,96 @@
+package dataflash
+
+import "sort"
+
+// schemaVersion is a definition of a message type, in effect from the FMT
+// message at offset until the type is redefined.
+type schemaVersion struct {
+	offset int64 // Offset of the FMT message
+	schema *Schema
+}
+
+// SchemaChange describes a message type being defined or redefined by a FMT
+// message.
+type SchemaChange struct {
+	Offset int64   // Offset of the FMT message
+	Old    *Schema // Definition until Offset, nil if the type was not defined before
+	New    *Schema // Definition from Offset on
+}
+
+// SchemaAt returns the schema that messages of msgType at offset are decoded
+// with: the last definition before offset. Messages before the first FMT of
+// their type use that first definition. It returns nil for unknown types.
+func (p *Parser) SchemaAt(msgType uint8, offset int64) *Schema {
+	versions := p.versions[msgType]
+	if len(versions) == 0 {
+		return nil
+	}
+	i := sort.Search(len(versions), func(i int) bool {
+		return versions[i].offset >= offset
+	})
+	if i == 0 {
+		return versions[0].schema
+	}
+	return versions[i-1].schema
+}
+
+// SchemaVersions returns every definition of msgType found so far, in log
+// order. Most logs define each type once.
+func (p *Parser) SchemaVersions(msgType uint8) []*Schema {
+	versions := p.versions[msgType]
+	schemas := make([]*Schema, len(versions))
+	for i, v := range versions {
+		schemas[i] = v.schema
+	}
+	return schemas
+}
+
+// addSchema records schema as defined by the FMT message at offset. A FMT
+// that was already read, or that repeats the definition in effect, changes
+// nothing, so units from FMTU messages are kept.
+func (p *Parser) addSchema(offset int64, schema *Schema) {
+	versions := p.versions[schema.Type]
+	i := sort.Search(len(versions), func(i int) bool {
+		return versions[i].offset >= offset
+	})
+	if i < len(versions) && versions[i].offset == offset {
+		return
+	}
+	var old *Schema
+	if i > 0 {
+		old = versions[i-1].schema
+		if sameLayout(old, schema) {
+			return
+		}
+	}
+
+	versions = append(versions, schemaVersion{})
+	copy(versions[i+1:], versions[i:])
+	versions[i] = schemaVersion{offset: offset, schema: schema}
+	p.versions[schema.Type] = versions
+	if i == len(versions)-1 {
+		p.schemas[schema.Type] = schema
+	}
+
+	if p.opts.schemaChange != nil {
+		p.opts.schemaChange(SchemaChange{Offset: offset, Old: old, New: schema})
+	}
+}
+
+// hasSchema reports whether any definition of any type is named name.
+func (p *Parser) hasSchema(name string) bool {
+	for _, versions := range p.versions {
+		for _, v := range versions {
+			if v.schema.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sameLayout reports whether a and b define the same message.
+func sameLayout(a, b *Schema) bool {
+	return a.Type == b.Type && a.Length == b.Length && a.Name == b.Name &&
+		a.Format == b.Format && a.Columns == b.Columns
+}
@@ -0,0 +1,167 @@
+package dataflash
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testIMU2Schema redefines the IMU type with a different layout, as a log
+// concatenated from another firmware would.
+var testIMU2Schema = &Schema{Type: testIMUType, Length: 20, Name: "IMU", Format: "QBff", Columns: "TimeUS,I,AccX,AccY"}
+
+// redefinedLog returns a log with n IMU messages in the original layout,
+// then a repeated preamble that redefines IMU, and n IMU messages in the new
+// layout. A GPS message follows every 10th IMU message.
+func redefinedLog(n int) []byte {
+	var l testLog
+	l.writePreamble()
+	for i := range n {
+		timeUS := uint64(1000 * (i + 1))
+		l.writeIMU(timeUS)
+		if (i+1)%10 == 0 {
+			l.writeGPS(timeUS, 50.45, 30.52)
+		}
+	}
+
+	l.writeFMT(fmtSchema)
+	l.writeFMT(testFMTUSchema)
+	l.writeFMT(testIMU2Schema)
+	l.writeFMT(testGPSSchema)
+	l.writeFMTU(0, testIMUType, "s#oo", "F-00")
+	l.writeFMTU(0, testGPSType, "s-DUm", "F-GGB")
+	for i := range n {
+		timeUS := uint64(1000 * (i + 1))
+		l.writeMessage(testIMUType, timeUS, uint8(1), float32(9.8), float32(-9.8))
+		if (i+1)%10 == 0 {
+			l.writeGPS(timeUS, 50.45, 30.52)
+		}
+	}
+	return l.Bytes()
+}
+
+func TestSchemaRedefinition(t *testing.T) {
+	for _, prescan := range []bool{true, false} {
+		var changes []SchemaChange
+		parser, err := NewParserFromBytes(redefinedLog(20), WithPrescan(prescan),
+			WithSchemaChange(func(c SchemaChange) { changes = append(changes, c) }))
+		if err != nil {
+			t.Fatalf("failed to create parser: %v", err)
+		}
+
+		// Read the log twice; changes are reported once
+		var imu []*Message
+		for range 2 {
+			imu = nil
+			for msg, err := range parser.Filtered("IMU") {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				imu = append(imu, msg)
+			}
+		}
+
+		if len(imu) != 40 {
+			t.Fatalf("prescan %v: expected 40 IMU messages, got %d", prescan, len(imu))
+		}
+		if _, ok := imu[19].Fields["GyrX"]; !ok {
+			t.Errorf("prescan %v: expected the original layout, got %v", prescan, imu[19].Fields)
+		}
+		if imu[20].Fields["AccX"] != float32(9.8) || imu[20].TimeUS != 1000 {
+			t.Errorf("prescan %v: expected the new layout, got %v", prescan, imu[20].Fields)
+		}
+		if _, unit, _ := imu[20].GetScaled("AccX"); unit != "m/s/s" {
+			t.Errorf("prescan %v: expected units of the new FMTU, got %q", prescan, unit)
+		}
+		if _, unit, _ := imu[0].GetScaled("GyrX"); unit != "rad/s" {
+			t.Errorf("prescan %v: expected units of the first FMTU, got %q", prescan, unit)
+		}
+
+		// FMT, FMTU, IMU and GPS are defined, then only IMU changes
+		if len(changes) != 5 {
+			t.Fatalf("prescan %v: expected 5 schema changes, got %d", prescan, len(changes))
+		}
+		last := changes[4]
+		if last.Old == nil || last.Old.Format != "QBfff" || last.New.Format != "QBff" {
+			t.Errorf("prescan %v: unexpected change %+v", prescan, last)
+		}
+		for _, c := range changes[:4] {
+			if c.Old != nil {
+				t.Errorf("prescan %v: expected a first definition, got %+v", prescan, c)
+			}
+		}
+
+		versions := parser.SchemaVersions(testIMUType)
+		if len(versions) != 2 || parser.GetSchemas()[testIMUType] != versions[1] {
+			t.Errorf("prescan %v: unexpected versions %v", prescan, versions)
+		}
+		if got := parser.SchemaAt(testIMUType, imu[19].Offset); got != versions[0] {
+			t.Errorf("prescan %v: expected first version before the redefinition", prescan)
+		}
+		if got := parser.SchemaAt(testIMUType, last.Offset+1); got != versions[1] {
+			t.Errorf("prescan %v: expected second version after the redefinition", prescan)
+		}
+	}
+}
+
+func TestLateSchema(t *testing.T) {
+	// A subsystem that starts logging mid-flight writes its FMT late
+	late := &Schema{Type: 150, Length: 12, Name: "LATE", Format: "QB", Columns: "TimeUS,V"}
+
+	var l testLog
+	l.writePreamble()
+	l.writeIMU(1000)
+	l.writeFMT(late)
+	l.writeMessage(late.Type, uint64(2000), uint8(7))
+	l.writeIMU(3000)
+
+	parser, err := NewParserFromBytes(l.Bytes())
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	var names []string
+	for msg, err := range parser.All() {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		names = append(names, msg.Name)
+		if msg.Name == "LATE" && msg.Fields["V"] != uint8(7) {
+			t.Errorf("unexpected LATE fields: %v", msg.Fields)
+		}
+	}
+
+	expected := []string{"FMT", "FMT", "FMT", "FMT", "FMTU", "FMTU", "IMU", "FMT", "LATE", "IMU"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("got %v, want %v", names, expected)
+	}
+}
+
+func TestEarlyMessage(t *testing.T) {
+	// A message written before its FMT decodes with the pre-scanned schema
+	late := &Schema{Type: 150, Length: 12, Name: "LATE", Format: "QB", Columns: "TimeUS,V"}
+
+	var l testLog
+	l.writePreamble()
+	l.writeMessage(late.Type, uint64(1000), uint8(7))
+	l.writeFMT(late)
+	l.writeMessage(late.Type, uint64(2000), uint8(8))
+
+	for _, mode := range []ParseMode{Tolerant, Strict} {
+		parser, err := NewParserFromBytes(l.Bytes(), WithParseMode(mode))
+		if err != nil {
+			t.Fatalf("failed to create parser: %v", err)
+		}
+		var values []any
+		for msg, err := range parser.Filtered("LATE") {
+			if err != nil {
+				t.Fatalf("mode %v: unexpected error: %v", mode, err)
+			}
+			values = append(values, msg.Fields["V"])
+		}
+		if !reflect.DeepEqual(values, []any{uint8(7), uint8(8)}) {
+			t.Errorf("mode %v: expected both LATE messages, got %v", mode, values)
+		}
+		if diag := parser.Diagnostics(); len(diag.UnknownTypes) != 0 {
+			t.Errorf("mode %v: unexpected unknown types %v", mode, diag.UnknownTypes)
+		}
+	}
+}
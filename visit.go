@@ -0,0 +1,201 @@
+package dataflash
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// MessageHeader describes a message passed to a Visit callback.
+type MessageHeader struct {
+	Type   uint8  // Message type ID
+	Name   string // Message name
+	LineNo int64  // Message sequence number in the log
+	TimeUS int64  // Microseconds since boot (0 if not available)
+	Offset int64  // Byte offset of the message header in the log
+}
+
+// FieldReader decodes fields of a single message directly from its body.
+// Fields are addressed by their index in Schema.Fields, see
+// Schema.ColumnIndex. A FieldReader passed to a Visit callback is reused and
+// only valid during the call.
+type FieldReader struct {
+	schema *Schema
+	body   []byte
+}
+
+// Schema returns the schema of the current message.
+func (r *FieldReader) Schema() *Schema {
+	return r.schema
+}
+
+// Len returns the number of fields in the current message.
+func (r *FieldReader) Len() int {
+	return len(r.schema.fields)
+}
+
+// field returns the format character and raw bytes of field i, or 0 if i is
+// out of range or the body is too short.
+func (r *FieldReader) field(i int) (rune, []byte) {
+	if i < 0 || i >= len(r.schema.fields) {
+		return 0, nil
+	}
+	field := &r.schema.fields[i]
+	end := field.Offset + field.Size
+	if end > len(r.body) {
+		return 0, nil
+	}
+	return field.Format, r.body[field.Offset:end]
+}
+
+// Float64 returns field i as a float64, applying the same fixed scaling as
+// DecodeMessageBody for the c, C, e, E and L formats. It returns 0 for
+// string and array fields and invalid indexes.
+func (r *FieldReader) Float64(i int) float64 {
+	dataType, b := r.field(i)
+	switch dataType {
+	case 'f':
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+	case 'g':
+		return float64(float16to32(binary.LittleEndian.Uint16(b)))
+	case 'd':
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	case 'c':
+		return float64(int16(binary.LittleEndian.Uint16(b))) * 0.01
+	case 'C':
+		return float64(binary.LittleEndian.Uint16(b)) * 0.01
+	case 'e':
+		return float64(int32(binary.LittleEndian.Uint32(b))) * 0.01
+	case 'E':
+		return float64(binary.LittleEndian.Uint32(b)) * 0.01
+	case 'L':
+		return float64(int32(binary.LittleEndian.Uint32(b))) * 1e-7
+	case 'B', 'M', 'H', 'I', 'Q':
+		return float64(r.Uint64(i))
+	case 'b', 'h', 'i', 'q':
+		return float64(r.Int64(i))
+	}
+	return 0
+}
+
+// Int64 returns integer field i as an int64. Scaled fields (c, C, e, E, L)
+// yield their raw stored integer; use Float64 for the scaled value. It
+// returns 0 for float and string fields and invalid indexes.
+func (r *FieldReader) Int64(i int) int64 {
+	dataType, b := r.field(i)
+	switch dataType {
+	case 'b':
+		return int64(int8(b[0]))
+	case 'h', 'c':
+		return int64(int16(binary.LittleEndian.Uint16(b)))
+	case 'i', 'e', 'L':
+		return int64(int32(binary.LittleEndian.Uint32(b)))
+	case 'q':
+		return int64(binary.LittleEndian.Uint64(b))
+	case 'B', 'M', 'H', 'C', 'I', 'E', 'Q':
+		return int64(r.Uint64(i))
+	}
+	return 0
+}
+
+// Uint64 returns unsigned integer field i as a uint64. Signed fields are
+// converted as by Int64. It returns 0 for float and string fields and
+// invalid indexes.
+func (r *FieldReader) Uint64(i int) uint64 {
+	dataType, b := r.field(i)
+	switch dataType {
+	case 'B', 'M':
+		return uint64(b[0])
+	case 'H', 'C':
+		return uint64(binary.LittleEndian.Uint16(b))
+	case 'I', 'E':
+		return uint64(binary.LittleEndian.Uint32(b))
+	case 'Q':
+		return binary.LittleEndian.Uint64(b)
+	case 'b', 'h', 'c', 'i', 'e', 'L', 'q':
+		return uint64(r.Int64(i))
+	}
+	return 0
+}
+
+// Int16Array appends the 32 values of array field i (format a) to dst and
+// returns the extended slice. It returns dst unchanged for other fields.
+func (r *FieldReader) Int16Array(i int, dst []int16) []int16 {
+	dataType, b := r.field(i)
+	if dataType != 'a' {
+		return dst
+	}
+	for j := 0; j < len(b); j += 2 {
+		dst = append(dst, int16(binary.LittleEndian.Uint16(b[j:])))
+	}
+	return dst
+}
+
+// Bytes returns string field i (n, N or Z) with trailing NULs removed. The
+// slice points into the read buffer and is only valid during the callback.
+func (r *FieldReader) Bytes(i int) []byte {
+	dataType, b := r.field(i)
+	switch dataType {
+	case 'n', 'N', 'Z':
+		return bytes.TrimRight(b, "\x00")
+	}
+	return nil
+}
+
+// String returns string field i (n, N or Z) with trailing NULs removed, or ""
+// for other fields. Unlike the other accessors it allocates.
+func (r *FieldReader) String(i int) string {
+	return string(r.Bytes(i))
+}
+
+// Visit calls fn for each message (subject to the filter) from the current
+// position to the end of the log. Unlike ReadMessage it decodes nothing up
+// front: fn reads only the fields it needs through the FieldReader, which
+// avoids the per-message map and boxing. Visit stops at the first error
+// returned by fn and returns it, and at a message whose schema cannot be
+// decoded; it returns nil at the end of the log, including a truncated last
+// message.
+func (p *Parser) Visit(fn func(h MessageHeader, r *FieldReader) error) error {
+	return p.VisitContext(context.Background(), fn)
+}
+
+// VisitContext is like Visit but stops with ctx.Err() once ctx is done.
+func (p *Parser) VisitContext(ctx context.Context, fn func(h MessageHeader, r *FieldReader) error) error {
+	var r FieldReader
+	for n := int64(0); ; n++ {
+		if err := checkContext(ctx, n); err != nil {
+			return err
+		}
+
+		msgType, schema, body, err := p.nextBody()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if schema.err != nil {
+			return fmt.Errorf("failed to decode message: %w", schema.err)
+		}
+
+		h := MessageHeader{
+			Type:   msgType,
+			Name:   schema.Name,
+			LineNo: p.lineNo,
+			Offset: p.offset,
+		}
+		if offset := schema.timeUSOffset(); offset >= 0 {
+			h.TimeUS = int64(binary.LittleEndian.Uint64(body[offset:]))
+		}
+
+		r.schema = schema
+		r.body = body
+		if err := fn(h, &r); err != nil {
+			return err
+		}
+	}
+}
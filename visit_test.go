@@ -0,0 +1,208 @@
+package dataflash
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVisit(t *testing.T) {
+	data := sampleLog(100)
+	expected, err := NewParserFromBytes(data)
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	parser, err := NewParserFromBytes(data)
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	count := 0
+	err = parser.Visit(func(h MessageHeader, r *FieldReader) error {
+		msg, err := expected.ReadMessage()
+		if err != nil {
+			t.Fatalf("failed to read message: %v", err)
+		}
+		count++
+		if h.Name != msg.Name || h.LineNo != msg.LineNo || h.TimeUS != msg.TimeUS || h.Offset != msg.Offset {
+			t.Fatalf("header mismatch: got %+v, want %s line %d time %d offset %d",
+				h, msg.Name, msg.LineNo, msg.TimeUS, msg.Offset)
+		}
+
+		schema := r.Schema()
+		if r.Len() != len(msg.Fields) {
+			t.Fatalf("%s: expected %d fields, got %d", h.Name, len(msg.Fields), r.Len())
+		}
+		for i, field := range schema.Fields() {
+			column := field.Name
+			var got any
+			switch v := msg.Fields[column].(type) {
+			case string:
+				got = r.String(i)
+			case float32:
+				got = float32(r.Float64(i))
+			case float64:
+				got = r.Float64(i)
+			case uint8:
+				got = uint8(r.Uint64(i))
+			case uint64:
+				got = r.Uint64(i)
+			case int8:
+				got = int8(r.Int64(i))
+			default:
+				t.Fatalf("%s.%s: unexpected type %T", h.Name, column, v)
+			}
+			if got != msg.Fields[column] {
+				t.Errorf("%s.%s: expected %v, got %v", h.Name, column, msg.Fields[column], got)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 116 {
+		t.Errorf("expected 116 messages, got %d", count)
+	}
+}
+
+func TestVisitFilterAndStop(t *testing.T) {
+	parser, err := NewParserFromBytes(sampleLog(100))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if err := parser.SetFilter("GPS"); err != nil {
+		t.Fatalf("failed to set filter: %v", err)
+	}
+
+	schema := parser.GetSchemas()[testGPSType]
+	alt := schema.ColumnIndex("Alt")
+	if alt < 0 || schema.ColumnIndex("Missing") != -1 {
+		t.Fatalf("unexpected column indexes: Alt=%d", alt)
+	}
+
+	stop := errors.New("stop")
+	count := 0
+	err = parser.Visit(func(h MessageHeader, r *FieldReader) error {
+		if h.Name != "GPS" {
+			t.Errorf("expected GPS, got %s", h.Name)
+		}
+		if got := r.Float64(alt); got != 275.3 {
+			t.Errorf("expected Alt 275.3, got %v", got)
+		}
+		if got := r.Int64(alt); got != 27530 {
+			t.Errorf("expected raw Alt 27530, got %d", got)
+		}
+		if r.Float64(-1) != 0 || r.Int64(r.Len()) != 0 || r.Bytes(alt) != nil {
+			t.Error("expected zero values for invalid access")
+		}
+		count++
+		if count == 3 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Fatalf("expected stop error, got %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 messages, got %d", count)
+	}
+}
+
+func TestVisitAllocs(t *testing.T) {
+	data := sampleLog(1000)
+	parser, err := NewParserFromBytes(data)
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	gyrX := parser.GetSchemas()[testIMUType].ColumnIndex("GyrX")
+
+	sum := 0.0
+	visit := func(h MessageHeader, r *FieldReader) error {
+		if h.Type == testIMUType {
+			sum += r.Float64(gyrX)
+		}
+		return nil
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		if err := parser.Rewind(); err != nil {
+			t.Fatalf("failed to rewind: %v", err)
+		}
+		if err := parser.Visit(visit); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if allocs > 1 {
+		t.Errorf("expected no per-message allocations, got %v per pass", allocs)
+	}
+}
+
+func BenchmarkVisit(b *testing.B) {
+	data := sampleLog(100000)
+	parser, err := NewParserFromBytes(data)
+	if err != nil {
+		b.Fatalf("failed to create parser: %v", err)
+	}
+	gyrX := parser.GetSchemas()[testIMUType].ColumnIndex("GyrX")
+	gyrY := parser.GetSchemas()[testIMUType].ColumnIndex("GyrY")
+
+	sum := 0.0
+	b.SetBytes(int64(len(data)))
+	for b.Loop() {
+		if err := parser.Rewind(); err != nil {
+			b.Fatalf("failed to rewind: %v", err)
+		}
+		err := parser.Visit(func(h MessageHeader, r *FieldReader) error {
+			if h.Type == testIMUType {
+				sum += r.Float64(gyrX) + r.Float64(gyrY)
+			}
+			return nil
+		})
+		if err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestVisitArrayAndUnknownFormat(t *testing.T) {
+	isbd := &Schema{Type: 140, Length: 76, Name: "ISBD", Format: "QMa", Columns: "TimeUS,Mode,x"}
+	bad := &Schema{Type: 141, Length: 12, Name: "BAD", Format: "QX", Columns: "TimeUS,X"}
+
+	var samples [32]int16
+	for i := range samples {
+		samples[i] = int16(-i)
+	}
+	var l testLog
+	l.writeFMT(fmtSchema)
+	l.writeFMT(isbd)
+	l.writeFMT(bad)
+	l.writeMessage(isbd.Type, uint64(5000), uint8(3), samples)
+	l.writeMessage(bad.Type, uint64(6000), uint8(0), uint8(0), uint8(0), uint8(0))
+
+	parser, err := NewParserFromBytes(l.Bytes())
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if err := parser.SetFilter("ISBD", "BAD"); err != nil {
+		t.Fatalf("failed to set filter: %v", err)
+	}
+
+	visited := 0
+	err = parser.Visit(func(h MessageHeader, r *FieldReader) error {
+		visited++
+		if h.TimeUS != 5000 || r.Uint64(1) != 3 {
+			t.Errorf("unexpected message: %+v mode %d", h, r.Uint64(1))
+		}
+		got := r.Int16Array(2, nil)
+		if len(got) != 32 || got[0] != 0 || got[31] != -31 {
+			t.Errorf("unexpected samples: %v", got)
+		}
+		return nil
+	})
+	if !errors.Is(err, ErrUnknownFormatChar) {
+		t.Errorf("expected ErrUnknownFormatChar, got %v", err)
+	}
+	if visited != 1 {
+		t.Errorf("expected 1 message before the error, got %d", visited)
+	}
+}
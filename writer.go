@@ -0,0 +1,182 @@
+package dataflash
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Writer writes DataFlash logs. Schemas are defined with WriteSchema, or by
+// writing FMT messages with WriteMessage, before messages of their type are
+// written. Writing every message read from a log reproduces the log byte
+// for byte, apart from data the parser skipped as corrupt.
+//
+// A Writer buffers its output; call Flush when done.
+type Writer struct {
+	w       *bufio.Writer
+	schemas map[uint8]*Schema  // Schemas by type ID
+	byName  map[string]*Schema // Schemas by name
+	buf     []byte             // Message being encoded
+	timeUS  uint64             // Last TimeUS written, for FMTU, UNIT and MULT
+}
+
+// NewWriter returns a Writer writing to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		w:       bufio.NewWriter(w),
+		schemas: make(map[uint8]*Schema),
+		byName:  make(map[string]*Schema),
+	}
+}
+
+// WriteSchema writes a FMT message defining s and, if s has units or
+// multipliers, a FMTU message attaching them; a FMTU schema must have been
+// defined first. The FMT message describing FMT itself, which logs start
+// with, is written first if it hasn't been. s must be valid, see
+// Schema.Validate.
+func (w *Writer) WriteSchema(s *Schema) error {
+	if err := s.Validate(); err != nil {
+		return fmt.Errorf("failed to write schema: %w", err)
+	}
+	hasUnits := s.Units != "" || s.Mults != ""
+	if hasUnits {
+		if len(s.Units) != len(s.Fields()) || len(s.Mults) != len(s.Fields()) {
+			return fmt.Errorf("failed to write schema %s: %d units and %d multipliers for %d fields",
+				s.Name, len(s.Units), len(s.Mults), len(s.Fields()))
+		}
+		if _, ok := w.byName["FMTU"]; !ok {
+			return fmt.Errorf("failed to write schema %s: FMTU schema not defined", s.Name)
+		}
+	}
+
+	if _, ok := w.schemas[FMTType]; !ok && s.Type != FMTType {
+		if err := w.writeFMT(fmtSchema); err != nil {
+			return err
+		}
+	}
+	if err := w.writeFMT(s); err != nil {
+		return err
+	}
+
+	if !hasUnits {
+		return nil
+	}
+	return w.writeNamed("FMTU", map[string]any{
+		"TimeUS":  w.timeUS,
+		"FmtType": s.Type,
+		"UnitIds": s.Units,
+		"MultIds": s.Mults,
+	})
+}
+
+// writeFMT writes a FMT message for s.
+func (w *Writer) writeFMT(s *Schema) error {
+	return w.WriteMessage(&Message{Type: FMTType, Name: "FMT", Fields: map[string]any{
+		"Type":    s.Type,
+		"Length":  s.Length,
+		"Name":    s.Name,
+		"Format":  s.Format,
+		"Columns": s.Columns,
+	}})
+}
+
+// WriteUnit writes a UNIT message naming the unit identifier id. A UNIT
+// schema must have been defined first.
+func (w *Writer) WriteUnit(id rune, label string) error {
+	if id < 0 || id > 127 {
+		return fmt.Errorf("failed to write unit: identifier %q is not ASCII", id)
+	}
+	return w.writeNamed("UNIT", map[string]any{
+		"TimeUS": w.timeUS,
+		"Id":     int8(id),
+		"Label":  label,
+	})
+}
+
+// WriteMult writes a MULT message defining the multiplier identifier id. A
+// MULT schema must have been defined first.
+func (w *Writer) WriteMult(id rune, mult float64) error {
+	if id < 0 || id > 127 {
+		return fmt.Errorf("failed to write multiplier: identifier %q is not ASCII", id)
+	}
+	return w.writeNamed("MULT", map[string]any{
+		"TimeUS": w.timeUS,
+		"Id":     int8(id),
+		"Mult":   mult,
+	})
+}
+
+// writeNamed writes a message of the schema with the given name.
+func (w *Writer) writeNamed(name string, fields map[string]any) error {
+	s, ok := w.byName[name]
+	if !ok {
+		return fmt.Errorf("failed to write %s: schema not defined", name)
+	}
+	return w.WriteMessage(&Message{Type: s.Type, Name: name, Fields: fields})
+}
+
+// WriteMessage encodes msg.Fields with the schema of msg.Type, or of
+// msg.Name if the type is not defined under that name, and writes the
+// message. See EncodeMessageBody for how values are converted. Writing a
+// FMT message defines the schema it describes, as reading one does.
+func (w *Writer) WriteMessage(msg *Message) error {
+	schema, ok := w.schemas[msg.Type]
+	if !ok && msg.Type == FMTType {
+		schema, ok = fmtSchema, true
+	}
+	if !ok || msg.Name != "" && schema.Name != msg.Name {
+		if schema, ok = w.byName[msg.Name]; !ok {
+			return fmt.Errorf("failed to write %s: type %d is not defined", msg.Name, msg.Type)
+		}
+	}
+
+	w.buf = append(w.buf[:0], HEAD1, HEAD2, schema.Type)
+	buf, err := appendMessageBody(w.buf, msg.Fields, schema)
+	if err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	w.buf = buf
+	if _, err := w.w.Write(w.buf); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+
+	if schema.timeUSOffset() >= 0 {
+		if timeUS, err := unsignedValue(msg.Fields["TimeUS"], 64); err == nil {
+			w.timeUS = timeUS
+		}
+	}
+	if schema.Name == "FMT" {
+		w.define(msg.Fields)
+	}
+	return nil
+}
+
+// define records the schema described by the fields of a FMT message.
+func (w *Writer) define(fields map[string]any) {
+	typ, _ := unsignedValue(fields["Type"], 8)
+	length, _ := unsignedValue(fields["Length"], 8)
+	name, _ := fields["Name"].(string)
+	format, _ := fields["Format"].(string)
+	columns, _ := fields["Columns"].(string)
+	schema := &Schema{
+		Type:    uint8(typ),
+		Length:  uint8(length),
+		Name:    cString(name),
+		Format:  cString(format),
+		Columns: cString(columns),
+	}
+	schema.compile()
+	if err := schema.Validate(); err != nil {
+		schema.err = err
+	}
+	w.schemas[schema.Type] = schema
+	w.byName[schema.Name] = schema
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (w *Writer) Flush() error {
+	if err := w.w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush: %w", err)
+	}
+	return nil
+}
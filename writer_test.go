@@ -0,0 +1,236 @@
+package dataflash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var (
+	testUnitSchema = &Schema{Type: 132, Length: 76, Name: "UNIT", Format: "QbZ", Columns: "TimeUS,Id,Label"}
+	testMultSchema = &Schema{Type: 133, Length: 20, Name: "MULT", Format: "Qbd", Columns: "TimeUS,Id,Mult"}
+	testAll1Schema = &Schema{Type: 134, Length: 3 + 57, Name: "ALL1", Format: "BMbHhIiQqfgdcCeE",
+		Columns: "B,M,b,H,h,I,i,Q,q,f,g,d,c,C,e,E"}
+	testAll2Schema = &Schema{Type: 135, Length: 3 + 152, Name: "ALL2", Format: "LnNZa", Columns: "L,n,N,Z,a"}
+)
+
+// allFormatsLog returns a log with messages using every format character,
+// with extreme values and strings that have bytes after their terminator.
+func allFormatsLog() []byte {
+	var l testLog
+	l.writePreamble()
+	l.writeFMT(testAll1Schema)
+	l.writeFMT(testAll2Schema)
+
+	var array [32]int16
+	for i := range array {
+		array[i] = int16(i * -1000)
+	}
+	for _, sign := range []int64{1, -1} {
+		l.writeMessage(testAll1Schema.Type,
+			uint8(255), uint8(7), int8(-128*sign), uint16(65535), int16(-32768*sign),
+			uint32(math.MaxUint32), int32(math.MinInt32*sign), uint64(math.MaxUint64), int64(math.MinInt64+1)*sign,
+			float32(-1.5e-40)*float32(sign), uint16(0x7e01), math.Inf(int(sign)),
+			int16(-12345*sign), uint16(54321), int32(-2147483648*sign), uint32(4294967295))
+		l.writeMessage(testAll2Schema.Type, int32(-1799999999*sign))
+		l.writeString("ab\x00c", 4)
+		l.writeString("name", 16)
+		l.writeString(strings.Repeat("z", 64), 64)
+		binary.Write(&l.Buffer, binary.LittleEndian, array)
+	}
+	return l.Bytes()
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	logs := map[string][]byte{
+		"sample":    sampleLog(50),
+		"redefined": redefinedLog(20),
+		"formats":   allFormatsLog(),
+	}
+	for name, data := range logs {
+		t.Run(name, func(t *testing.T) {
+			parser, err := NewParserFromBytes(data)
+			if err != nil {
+				t.Fatalf("failed to create parser: %v", err)
+			}
+
+			var out bytes.Buffer
+			w := NewWriter(&out)
+			for msg, err := range parser.All() {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if err := w.WriteMessage(msg); err != nil {
+					t.Fatalf("failed to write %s: %v", msg.Name, err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatalf("failed to flush: %v", err)
+			}
+
+			if !bytes.Equal(out.Bytes(), data) {
+				t.Errorf("written log differs: %d bytes, expected %d", out.Len(), len(data))
+			}
+		})
+	}
+}
+
+func TestWriterSchemas(t *testing.T) {
+	gps := testGPSSchema.clone()
+	gps.Units = "s-DUm"
+	gps.Mults = "F-GGB"
+
+	var out bytes.Buffer
+	w := NewWriter(&out)
+	if err := w.WriteSchema(gps); err == nil {
+		t.Error("expected an error for units without a FMTU schema")
+	}
+	for _, s := range []*Schema{testFMTUSchema, testUnitSchema, testMultSchema, gps} {
+		if err := w.WriteSchema(s); err != nil {
+			t.Fatalf("failed to write schema %s: %v", s.Name, err)
+		}
+	}
+	if err := w.WriteUnit('D', "deglatitude"); err != nil {
+		t.Fatalf("failed to write unit: %v", err)
+	}
+	if err := w.WriteMult('G', 1e-7); err != nil {
+		t.Fatalf("failed to write multiplier: %v", err)
+	}
+	err := w.WriteMessage(&Message{Name: "GPS", Fields: map[string]any{
+		"TimeUS": 5000,
+		"Status": 3,
+		"Lat":    50.45,
+		"Lng":    float32(30.52),
+		"Alt":    275.256,
+	}})
+	if err != nil {
+		t.Fatalf("failed to write message: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("failed to flush: %v", err)
+	}
+
+	parser, err := NewParserFromBytes(out.Bytes())
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if parser.Units()['D'] != "deglatitude" || parser.Multipliers()['G'] != 1e-7 {
+		t.Errorf("unexpected units %v and multipliers %v", parser.Units(), parser.Multipliers())
+	}
+	if err := parser.SetFilter("GPS"); err != nil {
+		t.Fatalf("failed to set filter: %v", err)
+	}
+	msg, err := parser.ReadMessage()
+	if err != nil {
+		t.Fatalf("failed to read message: %v", err)
+	}
+	if msg.TimeUS != 5000 || msg.Fields["Status"] != uint8(3) ||
+		math.Abs(msg.MustFloat64("Lat")-50.45) > 1e-7 || msg.Fields["Alt"] != 275.26 {
+		t.Errorf("unexpected fields: %v", msg.Fields)
+	}
+	if _, unit, _ := msg.GetScaled("TimeUS"); unit != "s" {
+		t.Errorf("expected TimeUS in seconds, got %q", unit)
+	}
+
+	// The preamble starts with FMT for FMT, so the log is a single boot
+	segments, err := parser.Segments()
+	if err != nil || len(segments) != 1 || segments[0].Messages != 9 {
+		t.Errorf("expected one segment of 9 messages, got %+v, %v", segments, err)
+	}
+}
+
+func TestEncodeMessageBody(t *testing.T) {
+	schema := &Schema{Type: 140, Length: 3 + 2 + 2 + 4 + 4 + 4 + 1 + 1, Name: "ENC", Format: "cCeELBb",
+		Columns: "c,C,e,E,L,B,b"}
+
+	body, err := EncodeMessageBody(map[string]any{
+		"c": -1.006, "C": 655.35, "e": 0.005, "E": 1, "L": -35.12345675, "B": true,
+	}, schema)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	fields, err := DecodeMessageBody(body, schema)
+	if err != nil {
+		t.Fatalf("failed to decode: %v", err)
+	}
+	expected := map[string]any{
+		"c": -1.01, "C": 655.35, "e": 0.01, "E": 1.0, "L": float64(int32(-351234568)) * 1e-7,
+		"B": uint8(1), "b": int8(0),
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected %v, got %v", expected, fields)
+	}
+
+	invalid := []map[string]any{
+		{"c": 327.68},
+		{"C": -0.01},
+		{"B": 256},
+		{"B": 1.5},
+		{"b": uint64(math.MaxUint64)},
+		{"L": math.NaN()},
+		{"c": "1"},
+	}
+	for _, fields := range invalid {
+		if _, err := EncodeMessageBody(fields, schema); err == nil {
+			t.Errorf("expected an error for %v", fields)
+		}
+	}
+
+	strs := &Schema{Type: 141, Length: 3 + 4 + 64, Name: "STR", Format: "na", Columns: "n,a"}
+	if _, err := EncodeMessageBody(map[string]any{"n": "toolong"}, strs); err == nil {
+		t.Error("expected an error for a string that doesn't fit")
+	}
+	if _, err := EncodeMessageBody(map[string]any{"a": make([]int16, 33)}, strs); err == nil {
+		t.Error("expected an error for an array that doesn't fit")
+	}
+}
+
+func TestFloat16Encoding(t *testing.T) {
+	// Every half-precision value survives decoding and encoding
+	for h := range 1 << 16 {
+		if got := float32to16(float16to32(uint16(h))); got != uint16(h) {
+			t.Fatalf("%#04x: round trip gave %#04x", h, got)
+		}
+	}
+
+	tests := []struct {
+		f    float32
+		want uint16
+	}{
+		{1, 0x3c00},
+		{65504, 0x7bff},
+		{65520, 0x7c00},                        // Rounds up to infinity
+		{1 + 1.0/2048, 0x3c00},                 // Tie, rounds to even
+		{1 + 3.0/2048, 0x3c02},                 // Tie, rounds to even
+		{float32(math.Ldexp(1, -24)), 0x0001},  // Smallest subnormal
+		{float32(math.Ldexp(1, -25)), 0x0000},  // Tie, rounds to zero
+		{float32(math.Ldexp(3, -26)), 0x0001},  // Above the tie
+		{float32(math.Ldexp(1, -30)), 0x0000},  // Underflow
+		{-float32(math.Ldexp(1, -30)), 0x8000}, // Negative zero
+		{float32(math.Inf(-1)), 0xfc00},
+	}
+	for _, tt := range tests {
+		if got := float32to16(tt.f); got != tt.want {
+			t.Errorf("%v: expected %#04x, got %#04x", tt.f, tt.want, got)
+		}
+	}
+	if nan := float32to16(float32(math.NaN())); nan&0x7c00 != 0x7c00 || nan&0x3ff == 0 {
+		t.Errorf("expected NaN, got %#04x", nan)
+	}
+}
+
+func TestWriterUndefinedType(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{})
+	if err := w.WriteMessage(&Message{Type: 200, Name: "XYZ"}); err == nil {
+		t.Error("expected an error for an undefined type")
+	}
+	if err := w.WriteUnit('D', "deglatitude"); err == nil {
+		t.Error("expected an error without a UNIT schema")
+	}
+	if err := w.WriteSchema(&Schema{Type: 150, Length: 10, Name: "BAD", Format: "QB", Columns: "TimeUS"}); err == nil {
+		t.Error("expected an error for an invalid schema")
+	}
+}